@@ -1,81 +1,208 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"rest-api/internal/auth"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"rest-api/internal/database"
 	"rest-api/internal/routes"
-	"rest-api/internal/task"
 	"rest-api/pkg/config"
+	"rest-api/pkg/i18n"
+	"rest-api/pkg/lifecycle"
+	"rest-api/pkg/logger"
+	"rest-api/pkg/metrics"
 	"rest-api/pkg/middlewares"
+	"rest-api/pkg/tracing"
 
 	_ "rest-api/docs"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 )
 
 func main() {
 	cfg := config.LoadConfig()
+	if _, err := logger.Setup(cfg); err != nil {
+		fatal("unable to set up logger", err)
+	}
+	if i18n.IsSupported(cfg.DefaultLocale) {
+		i18n.DefaultLocale = cfg.DefaultLocale
+	}
+
+	// Subcommand: go run ./cmd/main.go migrate <up|down [N]|status|create NAME>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
 
 	app := fiber.New(fiber.Config{
 		ErrorHandler: middlewares.ErrorHandler,
 		BodyLimit: 10 * 1024 * 1024, // 10 MB
+		DisableStartupMessage: true,
 	})
 
-	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} ${latency}\n",
+	app.Use(recover.New(recover.Config{
+		EnableStackTrace: true,
+		StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
+			slog.ErrorContext(c.UserContext(), "panic recovered", slog.Any("panic", e))
+		},
 	}))
-	
+	app.Use(middlewares.RequestID())
+	app.Use(middlewares.AuditContext())
+	app.Use(tracing.Middleware())
+	app.Use(metrics.Middleware())
+	app.Use(middlewares.Locale())
+	app.Use(middlewares.RequestLogger())
+
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: cfg.CorsOrigin,
 		AllowCredentials: true,
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Accept-Language, Authorization, X-Request-ID, traceparent, tracestate",
+		ExposeHeaders: "X-Request-ID",
 		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
 	}))
-	if err := database.Connect(cfg); err != nil {
-		log.Fatalf("Unable to connect to database: %v", err)
+	// Tracing dipasang sebelum koneksi database agar query migration ikut ter-trace
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		fatal("unable to set up tracing", err)
 	}
 
-	// Manual migration for vertical architecture
-	db := database.GetDB()
-	tables := []interface{}{
-		&auth.User{},
-		&task.Task{},
+	if err := database.Connect(cfg); err != nil {
+		fatal("unable to connect to database", err)
 	}
-	if err := db.AutoMigrate(tables...); err != nil {
-		log.Fatalf("Database migration failed: %v", err)
+
+	// Jalankan migration berversi (internal/database/migrations) saat startup
+	// Set DB_AUTO_MIGRATE=false untuk menjalankan migration terpisah via subcommand migrate
+	if cfg.DBAutoMigrate == "true" {
+		if err := database.Migrate(context.Background()); err != nil {
+			fatal("database migration failed", err)
+		}
 	}
-	log.Println("✅ Migrasi database berhasil.")
 
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"message": "Welcome to the REST API",
 			"version": "1.0.0",
-			"timestamp": fiber.Map{},
-		})	})
+			"timestamp": time.Now().UTC(),
+		})
+	})
 
 	// Swagger docs endpoint
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// Lifecycle: readiness, background worker dan shutdown hook
+	lc := lifecycle.New()
+	lc.OnShutdown("tracing", shutdownTracing)
+	lc.OnShutdown("database", database.Close)
+
+	setupMetrics(app, cfg, lc)
+
 	// Use vertical layer routes
-	routes.SetupVerticalRoutes(app, cfg)
+	if err := routes.SetupVerticalRoutes(app, cfg, lc); err != nil {
+		fatal("unable to set up routes", err)
+	}
 
 	app.Use(middlewares.NotFound)
 
+	// Tangkap SIGINT (Ctrl+C) dan SIGTERM (docker stop / kubernetes) untuk graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	port := cfg.Port
-	log.Printf("🚀 Server is running on port %s", port)
-	log.Printf("📍 Local: http://localhost:%s", port)
-	log.Printf("🌍 Environment: %s", cfg.NodeEnv)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- app.Listen(fmt.Sprintf(":%s", port))
+	}()
+
+	slog.Info("server started", slog.String("port", port), slog.String("env", cfg.NodeEnv))
+	lc.SetReady(true)
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			fatal("unable to start server", err)
+		}
+		return
+	case <-ctx.Done():
+	}
+	stop()
 
-	if err := app.Listen(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("❌ Unable to start server: %v", err)
+	shutdown(app, lc, cfg)
+}
+
+// setupMetrics mengekspos metric Prometheus
+// Jika METRICS_PORT diisi, /metrics dijalankan di server terpisah (contoh: hanya bisa diakses dari jaringan internal)
+func setupMetrics(app *fiber.App, cfg *config.Config, lc *lifecycle.Manager) {
+	if sqlDB, err := database.GetDB().DB(); err == nil {
+		if err := metrics.RegisterDB(sqlDB, cfg.DBName); err != nil {
+			slog.Warn("unable to register database metrics", slog.Any("error", err))
+		}
+	}
+
+	if cfg.MetricsPort == "" {
+		app.Get("/metrics", metrics.Handler(cfg.MetricsToken))
+		return
+	}
+
+	metricsApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	metricsApp.Get("/metrics", metrics.Handler(cfg.MetricsToken))
+	lc.OnShutdown("metrics server", metricsApp.ShutdownWithContext)
+
+	go func() {
+		if err := metricsApp.Listen(fmt.Sprintf(":%s", cfg.MetricsPort)); err != nil {
+			slog.Error("unable to start metrics server", slog.Any("error", err))
+		}
+	}()
+	slog.Info("metrics server started", slog.String("port", cfg.MetricsPort))
+}
+
+// shutdown menghentikan server secara berurutan:
+// readiness tidak siap -> selesaikan request berjalan -> hentikan worker -> tutup database
+// Semua langkah dibatasi oleh SHUTDOWN_TIMEOUT
+func shutdown(app *fiber.App, lc *lifecycle.Manager, cfg *config.Config) {
+	timeout, err := time.ParseDuration(cfg.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		slog.Warn("invalid SHUTDOWN_TIMEOUT, using 30s", slog.String("value", cfg.ShutdownTimeout))
+		timeout = 30 * time.Second
+	}
+
+	slog.Info("shutting down", slog.String("timeout", timeout.String()))
+	lc.BeginShutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		slog.Warn("http server shutdown failed", slog.Any("error", err))
+	}
+	if err := lc.Shutdown(ctx); err != nil {
+		fatal("shutdown failed", err)
 	}
 
+	slog.Info("server stopped")
+}
+
+// runMigrate menjalankan subcommand migrate lalu keluar
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 || args[0] != "create" {
+		if err := database.Connect(cfg); err != nil {
+			fatal("unable to connect to database", err)
+		}
+	}
+
+	if err := database.RunMigrateCommand(context.Background(), args); err != nil {
+		fatal("migrate failed", err)
+	}
+}
 
-}
\ No newline at end of file
+// fatal menulis error ke log lalu keluar dengan exit code 1
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
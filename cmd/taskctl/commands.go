@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/task"
+	"rest-api/internal/user"
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/validation"
+)
+
+// exportFile adalah format file hasil "tasks export"
+type exportFile struct {
+	Email      string         `json:"email"`
+	ExportedAt time.Time      `json:"exportedAt"`
+	Tasks      []exportedTask `json:"tasks"`
+}
+
+type exportedTask struct {
+	Title       string    `json:"title" validate:"required,max=255"`
+	Description string    `json:"description"`
+	IsCompleted bool      `json:"isCompleted"`
+	Priority    string    `json:"priority,omitempty" validate:"omitempty,oneof=none low medium high"`
+	Tags        []string  `json:"tags,omitempty" validate:"max=20,dive,min=1,max=50"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// createRequest mengubah task dari file export menjadi request CreateTask
+func (t exportedTask) createRequest() *task.CreateRequest {
+	return &task.CreateRequest{Title: t.Title, Description: t.Description, Priority: t.Priority, Tags: t.Tags}
+}
+
+// newFlagSet membuat FlagSet yang mengembalikan error (bukan exit) jika flag tidak valid
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// findUser mencari user berdasarkan email
+func (a *app) findUser(ctx context.Context, email string) (*user.Response, error) {
+	if email == "" {
+		return nil, errors.New("flag -email wajib diisi")
+	}
+	return a.users.GetUserByEmail(ctx, email)
+}
+
+func createUser(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user create")
+	username := fs.String("username", "", "username")
+	email := fs.String("email", "", "email")
+	password := fs.String("password", "", "password")
+	admin := fs.Bool("admin", false, "buat sebagai admin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := auth.RegisterRequest{Username: *username, Email: *email, Password: *password}
+	if err := validation.Struct(&req); err != nil {
+		return describe(err)
+	}
+
+	created, err := a.auth.Register(ctx, req.Username, req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	if *admin {
+		if err := a.users.SetRole(ctx, created.ID, user.RoleAdmin); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("User %s (id %d) created\n", created.Email, created.ID)
+	return nil
+}
+
+func resetPassword(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user reset-password")
+	email := fs.String("email", "", "email user")
+	password := fs.String("password", "", "password baru")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Gunakan aturan validasi yang sama dengan PUT /api/users/:id
+	if err := validation.Struct(&user.UpdateRequest{Password: password}); err != nil {
+		return describe(err)
+	}
+
+	target, err := a.findUser(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if err := a.users.ResetPassword(ctx, target.ID, *password); err != nil {
+		return err
+	}
+
+	fmt.Printf("Password for %s reset\n", target.Email)
+	return nil
+}
+
+func setRole(role string) func(ctx context.Context, a *app, args []string) error {
+	return func(ctx context.Context, a *app, args []string) error {
+		fs := newFlagSet("user role")
+		email := fs.String("email", "", "email user")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		target, err := a.findUser(ctx, *email)
+		if err != nil {
+			return err
+		}
+		if err := a.users.SetRole(ctx, target.ID, role); err != nil {
+			return err
+		}
+
+		fmt.Printf("User %s is now %s\n", target.Email, role)
+		return nil
+	}
+}
+
+func setDisabled(disabled bool) func(ctx context.Context, a *app, args []string) error {
+	return func(ctx context.Context, a *app, args []string) error {
+		fs := newFlagSet("user disable")
+		email := fs.String("email", "", "email user")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		target, err := a.findUser(ctx, *email)
+		if err != nil {
+			return err
+		}
+		if err := a.users.SetDisabled(ctx, target.ID, disabled); err != nil {
+			return err
+		}
+
+		state := "enabled"
+		if disabled {
+			state = "disabled"
+		}
+		fmt.Printf("User %s %s\n", target.Email, state)
+		return nil
+	}
+}
+
+func exportTasks(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tasks export")
+	email := fs.String("email", "", "email user")
+	out := fs.String("out", "", "file output (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	owner, err := a.findUser(ctx, *email)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := a.tasks.GetTasksByUserID(ctx, owner.ID, &task.ListQuery{Archived: task.ArchivedInclude})
+	if err != nil {
+		return err
+	}
+
+	file := exportFile{Email: owner.Email, ExportedAt: time.Now().UTC(), Tasks: make([]exportedTask, len(tasks))}
+	for i, t := range tasks {
+		file.Tasks[i] = exportedTask{
+			Title:       t.Title,
+			Description: t.Description,
+			IsCompleted: t.IsCompleted,
+			Priority:    t.Priority,
+			Tags:        t.Tags,
+			CreatedAt:   t.CreatedAt,
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return err
+	}
+
+	if *out != "" {
+		fmt.Printf("%d tasks exported to %s\n", len(tasks), *out)
+	}
+	return nil
+}
+
+// importTasks membuat task baru dari file hasil export
+// createdAt di file tidak dipakai, task yang diimport mendapat waktu pembuatan baru
+func importTasks(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tasks import")
+	email := fs.String("email", "", "email user")
+	in := fs.String("in", "", "file input (default: stdin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	owner, err := a.findUser(ctx, *email)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var file exportFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return fmt.Errorf("file import tidak valid: %w", err)
+	}
+
+	// Validasi semua task dulu agar import tidak berhenti di tengah jalan
+	for i := range file.Tasks {
+		if err := validation.Struct(&file.Tasks[i]); err != nil {
+			return fmt.Errorf("task #%d: %w", i+1, describe(err))
+		}
+	}
+
+	// Import dari task terlama agar urutan created_at sama dengan aslinya
+	for i := len(file.Tasks) - 1; i >= 0; i-- {
+		t := file.Tasks[i]
+		created, err := a.tasks.CreateTask(ctx, owner.ID, t.createRequest())
+		if err != nil {
+			return err
+		}
+		if t.IsCompleted {
+			completed := true
+			if _, err := a.tasks.UpdateTask(ctx, owner.ID, created.ID, &task.UpdateRequest{IsCompleted: &completed}); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("%d tasks imported for %s\n", len(file.Tasks), owner.Email)
+	return nil
+}
+
+// purgeTrash menghapus permanen task di trash tanpa menunggu background worker
+// -days 0 menghapus semua task di trash
+func purgeTrash(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tasks purge")
+	days := fs.Int("days", -1, "hapus task yang sudah di trash lebih dari N hari (default: TRASH_RETENTION_DAYS)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *days < 0 {
+		var err error
+		if *days, err = strconv.Atoi(a.cfg.TrashRetentionDays); err != nil || *days <= 0 {
+			return errors.New("TRASH_RETENTION_DAYS tidak diisi, gunakan flag -days")
+		}
+	}
+
+	// olderThan minimal 1ns agar -days 0 tetap menghapus (PurgeTrash mengabaikan olderThan <= 0)
+	olderThan := max(time.Duration(*days)*24*time.Hour, time.Nanosecond)
+	count, err := a.tasks.PurgeTrash(ctx, olderThan)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d tasks purged from trash\n", count)
+	return nil
+}
+
+// reindexSearch mengisi ulang search index dari tabel tasks
+// Dipakai jika index tidak sinkron, contoh: update index gagal atau data task diubah langsung di database
+func reindexSearch(ctx context.Context, a *app, args []string) error {
+	if err := newFlagSet("search reindex").Parse(args); err != nil {
+		return err
+	}
+
+	count, err := a.index.Rebuild(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d tasks indexed\n", count)
+	return nil
+}
+
+// seed membuat user demo beserta beberapa task contoh
+func seed(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("seed")
+	email := fs.String("email", "demo@example.com", "email user demo")
+	password := fs.String("password", "demo1234", "password user demo")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	demo, err := a.auth.Register(ctx, "demo", *email, *password)
+	if err != nil {
+		return err
+	}
+
+	samples := []exportedTask{
+		{Title: "Setup project", Description: "Clone repository dan install dependencies", IsCompleted: true},
+		{Title: "Baca dokumentasi API", Description: "Buka /swagger/index.html"},
+		{Title: "Buat task pertama", Description: "Coba endpoint POST /api/tasks"},
+	}
+	for _, sample := range samples {
+		created, err := a.tasks.CreateTask(ctx, demo.ID, sample.createRequest())
+		if err != nil {
+			return err
+		}
+		if sample.IsCompleted {
+			completed := true
+			if _, err := a.tasks.UpdateTask(ctx, demo.ID, created.ID, &task.UpdateRequest{IsCompleted: &completed}); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("Demo user %s / %s created with %d tasks\n", *email, *password, len(samples))
+	return nil
+}
+
+// describe mengubah error validasi menjadi pesan per field yang mudah dibaca di terminal
+func describe(err error) error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		if details, ok := appErr.Details.(validation.Errors); ok {
+			return details
+		}
+	}
+	return err
+}
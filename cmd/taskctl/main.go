@@ -0,0 +1,149 @@
+// Command taskctl adalah CLI admin untuk operasi operasional
+// Semua perintah memakai service yang sama dengan HTTP API (auth, user, task),
+// sehingga validasi dan aturan bisnisnya tetap sama
+//
+// Usage:
+//
+//	go run ./cmd/taskctl <command> [flags]
+//
+// Commands:
+//
+//	user create          -username U -email E -password P [-admin]
+//	user reset-password  -email E -password P
+//	user promote         -email E
+//	user demote          -email E
+//	user disable         -email E
+//	user enable          -email E
+//	tasks export         -email E [-out FILE]
+//	tasks import         -email E [-in FILE]
+//	tasks purge          [-days N]
+//	search reindex
+//	seed                 [-email E] [-password P]
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"rest-api/internal/audit"
+	"rest-api/internal/auth"
+	"rest-api/internal/database"
+	"rest-api/internal/search"
+	"rest-api/internal/task"
+	"rest-api/internal/user"
+	"rest-api/pkg/config"
+	"rest-api/pkg/security"
+)
+
+// app berisi service yang dipakai oleh semua command
+type app struct {
+	cfg   *config.Config
+	auth  auth.Service
+	users user.Service
+	tasks task.Service
+	index search.SearchIndex
+}
+
+// command adalah handler untuk satu perintah, args adalah flag setelah nama perintah
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = map[string]command{
+	"user create":         {"-username U -email E -password P [-admin]", createUser},
+	"user reset-password": {"-email E -password P", resetPassword},
+	"user promote":        {"-email E", setRole(user.RoleAdmin)},
+	"user demote":         {"-email E", setRole(user.RoleUser)},
+	"user disable":        {"-email E", setDisabled(true)},
+	"user enable":         {"-email E", setDisabled(false)},
+	"tasks export":        {"-email E [-out FILE]", exportTasks},
+	"tasks import":        {"-email E [-in FILE]", importTasks},
+	"tasks purge":         {"[-days N]", purgeTrash},
+	"search reindex":      {"", reindexSearch},
+	"seed":                {"[-email E] [-password P]", seed},
+}
+
+func main() {
+	log.SetFlags(0)
+
+	name, args, ok := parseCommand(os.Args[1:])
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+	if err := database.Connect(cfg); err != nil {
+		log.Fatalf("Unable to connect to database: %v", err)
+	}
+	if cfg.DBAutoMigrate == "true" {
+		if err := database.Migrate(context.Background()); err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := commands[name].run(context.Background(), a, args); err != nil {
+		log.Fatalf("❌ %s: %v", name, err)
+	}
+}
+
+// newApp membuat service dengan wiring yang sama seperti routes.SetupVerticalRoutes
+func newApp(cfg *config.Config) (*app, error) {
+	db := database.GetDB()
+	hasher, err := security.NewPasswordHasher(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perubahan dari CLI tetap dicatat di audit log (tanpa actor, IP dan user agent)
+	recorder := audit.NewService(audit.NewRepository(db), 0)
+
+	index := search.NewIndex(db)
+
+	return &app{
+		cfg:   cfg,
+		auth:  auth.NewService(auth.NewRepository(db), cfg, hasher, recorder),
+		users: user.NewService(user.NewRepository(db), hasher, recorder),
+		tasks: task.NewService(task.NewRepository(db), cfg, recorder, index),
+		index: index,
+	}, nil
+}
+
+// parseCommand mencari nama command (satu atau dua kata) dari argumen
+func parseCommand(args []string) (string, []string, bool) {
+	if len(args) >= 2 {
+		if _, ok := commands[args[0]+" "+args[1]]; ok {
+			return args[0] + " " + args[1], args[2:], true
+		}
+	}
+	if len(args) >= 1 {
+		if _, ok := commands[args[0]]; ok {
+			return args[0], args[1:], true
+		}
+	}
+	return "", nil, false
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("Usage: taskctl <command> [flags]\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-20s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprint(os.Stderr, b.String())
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary List audit logs
+// @Description Audit log semua user (khusus admin), terbaru lebih dulu
+// @Tags Audit
+// @Produce json
+// @Param actorId query int false "Actor user ID"
+// @Param action query string false "Action (contoh: task.update)"
+// @Param targetType query string false "Target type (user/task)"
+// @Param targetId query string false "Target ID"
+// @Param from query string false "Mulai dari (RFC 3339)"
+// @Param to query string false "Sampai sebelum (RFC 3339)"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 50, max 200)"
+// @Success 200 {object} response.SuccessResponse{data=ListResponse}
+// @Failure 401 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/audit [get]
+func (ctrl *Controller) List(c *fiber.Ctx) error {
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	result, err := ctrl.service.List(c.UserContext(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "AUDIT_LOGS_RETRIEVED", result)
+}
+
+// @Summary Get my activity
+// @Description Event audit yang dilakukan oleh atau menargetkan user yang sedang login
+// @Tags User
+// @Produce json
+// @Param from query string false "Mulai dari (RFC 3339)"
+// @Param to query string false "Sampai sebelum (RFC 3339)"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 50, max 200)"
+// @Success 200 {object} response.SuccessResponse{data=ListResponse}
+// @Failure 401 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/users/profile/activity [get]
+func (ctrl *Controller) Activity(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	result, err := ctrl.service.ListForUser(c.UserContext(), user.ID, &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "ACTIVITY_RETRIEVED", result)
+}
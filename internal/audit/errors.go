@@ -0,0 +1,8 @@
+package audit
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul audit
+var (
+	ErrAuditFetchFailed = apperror.Internal("AUDIT_FETCH_FAILED", "failed to retrieve audit logs", nil)
+)
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Log adalah satu event audit, tidak pernah di-update setelah dibuat
+type Log struct {
+	ID         uint      `gorm:"primaryKey"`
+	ActorID    *uint     // nil jika event tidak dilakukan user yang login (contoh: login gagal, CLI admin)
+	Action     string    `gorm:"size:50;not null"`
+	TargetType string    `gorm:"size:50;not null"`
+	TargetID   string    `gorm:"size:64;not null"`
+	Changes    string    `gorm:"type:text"` // JSON auditlog.Changes
+	Metadata   string    `gorm:"type:text"` // JSON metadata tambahan
+	IP         string    `gorm:"size:64;not null"`
+	UserAgent  string    `gorm:"size:191;not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (Log) TableName() string {
+	return "audit_logs"
+}
+
+// Query DTOs
+// Waktu memakai format RFC 3339 (contoh: 2025-01-31T00:00:00Z)
+type ListQuery struct {
+	ActorID    *uint  `json:"actorId" query:"actorId"`
+	Action     string `json:"action" query:"action" validate:"omitempty,max=50"`
+	TargetType string `json:"targetType" query:"targetType" validate:"omitempty,max=50"`
+	TargetID   string `json:"targetId" query:"targetId" validate:"omitempty,max=64"`
+	From       string `json:"from" query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `json:"to" query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Page       int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit      int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// Response DTOs
+type Response struct {
+	ID         uint            `json:"id"`
+	ActorID    *uint           `json:"actorId"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetId"`
+	Changes    json.RawMessage `json:"changes,omitempty" swaggertype:"object"`
+	Metadata   json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"userAgent"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type ListResponse struct {
+	Logs  []Response `json:"logs"`
+	Total int64      `json:"total"`
+	Page  int        `json:"page"`
+	Limit int        `json:"limit"`
+}
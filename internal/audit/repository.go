@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"rest-api/pkg/auditlog"
+
+	"gorm.io/gorm"
+)
+
+// Filter adalah kriteria pencarian audit log, field kosong/nil diabaikan
+type Filter struct {
+	ActorID    *uint
+	SubjectID  *uint // event yang dilakukan user ini atau yang menargetkan user ini
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+// Repository sengaja tidak punya Update: audit log hanya boleh ditambah (dan dihapus oleh retention)
+type Repository interface {
+	Create(ctx context.Context, log *Log) error
+	List(ctx context.Context, filter Filter) ([]Log, int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+func (r *repository) Create(ctx context.Context, log *Log) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+// List implements Repository.
+func (r *repository) List(ctx context.Context, filter Filter) ([]Log, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Log{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.SubjectID != nil {
+		subjectID := *filter.SubjectID
+		query = query.Where("actor_id = ? OR (target_type = ? AND target_id = ?)",
+			subjectID, auditlog.TargetUser, strconv.FormatUint(uint64(subjectID), 10))
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []Log
+	if err := query.
+		Order("created_at desc, id desc").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// DeleteBefore implements Repository.
+func (r *repository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&Log{})
+	return result.RowsAffected, result.Error
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
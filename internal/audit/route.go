@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	app.Get("/api/audit", middlewares.Auth(cfg), middlewares.Admin(), ctrl.List)
+	app.Get("/api/users/profile/activity", middlewares.Auth(cfg), ctrl.Activity)
+}
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"rest-api/pkg/auditlog"
+)
+
+// Pagination default untuk listing audit log
+const (
+	DefaultLimit = 50
+)
+
+// Service defines the interface for audit business logic
+// Service juga mengimplementasikan auditlog.Recorder untuk dipakai modul lain
+type Service interface {
+	auditlog.Recorder
+	List(ctx context.Context, query *ListQuery) (*ListResponse, error)
+	ListForUser(ctx context.Context, userID uint, query *ListQuery) (*ListResponse, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// service implements Service.
+type service struct {
+	repo      Repository
+	retention time.Duration
+}
+
+// Record implements auditlog.Recorder.
+// Context request tidak dipakai untuk pembatalan agar event tetap tersimpan walaupun client memutus koneksi
+func (s *service) Record(ctx context.Context, entry auditlog.Entry) {
+	client := auditlog.ClientFromContext(ctx)
+	log := &Log{
+		ActorID:    auditlog.Actor(ctx, entry),
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Changes:    encode(entry.Changes),
+		Metadata:   encode(entry.Metadata),
+		IP:         client.IP,
+		UserAgent:  truncate(client.UserAgent, 191),
+	}
+
+	if err := s.repo.Create(context.WithoutCancel(ctx), log); err != nil {
+		slog.ErrorContext(ctx, "failed to record audit event", slog.String("action", entry.Action), slog.Any("error", err))
+	}
+}
+
+// List implements Service.
+func (s *service) List(ctx context.Context, query *ListQuery) (*ListResponse, error) {
+	filter := toFilter(query)
+	filter.ActorID = query.ActorID
+	filter.Action = query.Action
+	filter.TargetType = query.TargetType
+	filter.TargetID = query.TargetID
+	return s.list(ctx, filter, query)
+}
+
+// ListForUser implements Service.
+// Hanya filter waktu dan pagination dari query yang dipakai
+func (s *service) ListForUser(ctx context.Context, userID uint, query *ListQuery) (*ListResponse, error) {
+	filter := toFilter(query)
+	filter.SubjectID = &userID
+	return s.list(ctx, filter, query)
+}
+
+// PurgeExpired implements Service.
+// Menghapus event yang lebih tua dari masa retensi, tidak melakukan apa pun jika retensi 0
+func (s *service) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeleteBefore(ctx, time.Now().UTC().Add(-s.retention))
+}
+
+func (s *service) list(ctx context.Context, filter Filter, query *ListQuery) (*ListResponse, error) {
+	logs, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, ErrAuditFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(logs))
+	for i, log := range logs {
+		responses[i] = toResponse(&log)
+	}
+
+	return &ListResponse{Logs: responses, Total: total, Page: filter.Offset/filter.Limit + 1, Limit: filter.Limit}, nil
+}
+
+// toFilter mengubah pagination dan rentang waktu dari query menjadi Filter
+// Format waktu sudah divalidasi oleh tag validate, sehingga error parse bisa diabaikan
+func toFilter(query *ListQuery) Filter {
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+
+	filter := Filter{Offset: (page - 1) * limit, Limit: limit}
+	if query.From != "" {
+		from, _ := time.Parse(time.RFC3339, query.From)
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, _ := time.Parse(time.RFC3339, query.To)
+		filter.To = &to
+	}
+	return filter
+}
+
+func toResponse(log *Log) Response {
+	response := Response{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		IP:         log.IP,
+		UserAgent:  log.UserAgent,
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.Changes != "" {
+		response.Changes = json.RawMessage(log.Changes)
+	}
+	if log.Metadata != "" {
+		response.Metadata = json.RawMessage(log.Metadata)
+	}
+	return response
+}
+
+// encode mengubah value menjadi JSON, string kosong jika value kosong
+func encode[T ~map[string]V, V any](value T) string {
+	if len(value) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
+
+// NewService membuat audit service, retention 0 berarti audit log disimpan selamanya
+func NewService(repo Repository, retention time.Duration) Service {
+	return &service{repo: repo, retention: retention}
+}
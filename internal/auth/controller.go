@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -28,19 +29,21 @@ func NewController(service Service, cfg *config.Config) *Controller {
 // @Produce json
 // @Param data body LoginRequest true "Login data"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/auth/login [post]
 func (ctrl *Controller) Login(c *fiber.Ctx) error {
 	var req LoginRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid JSON format")
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	// Call service untuk login
-	token, userResponse, err := ctrl.service.Login(req.Email, req.Password)
+	token, userResponse, err := ctrl.service.Login(c.UserContext(), req.Email, req.Password)
 	if err != nil {
-		return response.Error(c, fiber.StatusUnauthorized, err.Error())
+		return err
 	}
 
 	// Set cookie dengan token
@@ -53,7 +56,7 @@ func (ctrl *Controller) Login(c *fiber.Ctx) error {
 		SameSite: "Lax",
 	})
 
-	return response.Success(c, fiber.StatusOK, "Login successfully.", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "LOGIN_SUCCESS", fiber.Map{
 		"token": token,
 		"user":  userResponse,
 	})
@@ -66,22 +69,24 @@ func (ctrl *Controller) Login(c *fiber.Ctx) error {
 // @Produce json
 // @Param data body RegisterRequest true "User data"
 // @Success 201 {object} response.SuccessResponse
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/auth/register [post]
 func (ctrl *Controller) Register(c *fiber.Ctx) error {
 	var req RegisterRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid request body")
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	// Call service untuk register
-	userResponse, err := ctrl.service.Register(req.Username, req.Email, req.Password)
+	userResponse, err := ctrl.service.Register(c.UserContext(), req.Username, req.Email, req.Password)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusCreated, "User registered successfully.", fiber.Map{
+	return response.Success(c, fiber.StatusCreated, "REGISTER_SUCCESS", fiber.Map{
 		"user": userResponse,
 	})
 }
@@ -0,0 +1,16 @@
+package auth
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul auth
+var (
+	ErrInvalidCredentials = apperror.Unauthorized("INVALID_CREDENTIALS", "email atau password salah")
+	ErrUserAlreadyExists  = apperror.Conflict("USER_ALREADY_EXISTS", "email atau username sudah terdaftar")
+	ErrTokenMissing       = apperror.Unauthorized("TOKEN_MISSING", "Akses ditolak. Token tidak ditemukan.")
+	ErrTokenInvalid       = apperror.Unauthorized("TOKEN_INVALID", "Token tidak valid atau kadaluarsa.")
+	ErrAccountDisabled    = apperror.Forbidden("ACCOUNT_DISABLED", "Akun dinonaktifkan.")
+	ErrUserNotFound       = apperror.Unauthorized("USER_NOT_FOUND", "User tidak ditemukan.")
+	ErrAdminRequired      = apperror.Forbidden("ADMIN_REQUIRED", "Aksi ini hanya untuk admin.")
+	ErrLoginFailed        = apperror.Internal("LOGIN_FAILED", "failed to login", nil)
+	ErrRegisterFailed     = apperror.Internal("REGISTER_FAILED", "failed to register user", nil)
+)
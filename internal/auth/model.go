@@ -2,13 +2,32 @@ package auth
 
 import "time"
 
+// Role user
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Username   string     `json:"username" gorm:"unique;not null"`
+	Email      string     `json:"email" gorm:"unique;not null"`
+	Password   string     `json:"-" gorm:"not null"`
+	Locale     string     `json:"locale" gorm:"size:10"` // Preferensi bahasa (kosong = ikuti Accept-Language)
+	Role       string     `json:"role" gorm:"size:20;not null;default:user"`
+	DisabledAt *time.Time `json:"disabledAt"` // Akun dinonaktifkan oleh admin jika tidak nil
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsAdmin bernilai true jika user memiliki role admin
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsDisabled bernilai true jika akun user dinonaktifkan
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
 }
 
 // Request DTOs
@@ -28,6 +47,8 @@ type UserResponse struct {
 	ID        uint      `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
+	Locale    string    `json:"locale"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
-}
\ No newline at end of file
+}
@@ -1,42 +1,47 @@
 package auth
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindByEmail(email string) (*User, error)
-	FindEmailOrUsername(email, username string) (*User, error)
-	Register(user *User) error
-	FindByID(id uint) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindEmailOrUsername(ctx context.Context, email, username string) (*User, error)
+	Register(ctx context.Context, user *User) error
+	FindByID(ctx context.Context, id uint) (*User, error)
+	UpdatePassword(ctx context.Context, id uint, hashedPassword string) error
 }
 
+// Perbandingan email/username memakai LOWER() agar hasilnya sama di semua database
+// (collation default MySQL case-insensitive, PostgreSQL dan SQLite case-sensitive)
 type repository struct {
 	db *gorm.DB
 }
 
 // FindByEmail implements Repository.
-func (r *repository) FindByEmail(email string) (*User, error) {
+func (r *repository) FindByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // FindByID implements Repository.
-func (r *repository) FindByID(id uint) (*User, error) {
+func (r *repository) FindByID(ctx context.Context, id uint) (*User, error) {
 	var user User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // FindEmailOrUsername implements Repository.
-func (r *repository) FindEmailOrUsername(email string, username string) (*User, error) {
+func (r *repository) FindEmailOrUsername(ctx context.Context, email string, username string) (*User, error) {
 	var user User
-	err := r.db.Where("email = ? OR username = ?", email, username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("LOWER(email) = LOWER(?) OR LOWER(username) = LOWER(?)", email, username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +49,13 @@ func (r *repository) FindEmailOrUsername(email string, username string) (*User,
 }
 
 // Register implements Repository.
-func (r *repository) Register(user *User) error {
-	return r.db.Create(user).Error
+func (r *repository) Register(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+// UpdatePassword implements Repository.
+func (r *repository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("password", hashedPassword).Error
 }
 
 func NewRepository(db *gorm.DB) Repository {
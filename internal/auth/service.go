@@ -1,12 +1,17 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"log/slog"
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/auditlog"
 	"rest-api/pkg/config"
+	"rest-api/pkg/metrics"
+	"rest-api/pkg/security"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -17,14 +22,16 @@ type Claims struct {
 }
 
 type Service interface {
-	Register(username, email, password string) (*UserResponse, error)
-	Login(email, password string) (string, *UserResponse, error)
+	Register(ctx context.Context, username, email, password string) (*UserResponse, error)
+	Login(ctx context.Context, email, password string) (string, *UserResponse, error)
 	GenerateToken(userID uint) (string, error)
 }
 
 type service struct {
-	repo Repository
-	cfg  *config.Config
+	repo   Repository
+	cfg    *config.Config
+	hasher security.PasswordHasher
+	audit  auditlog.Recorder
 }
 
 // GenerateToken implements Service.
@@ -46,40 +53,68 @@ func (s *service) GenerateToken(userID uint) (string, error) {
 
 	// Create token with signing method HS256
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	
+
 	// Sign token with secret key and return token string
 	return token.SignedString([]byte(s.cfg.JWTSecret))
 }
 
 // Login implements Service.
-func (s *service) Login(email string, password string) (string, *UserResponse, error) {
-	if email == "" || password == "" {
-		return "", nil, errors.New("email dan password tidak boleh kosong")
+// Setiap percobaan login dicatat di metric auth_logins_total
+func (s *service) Login(ctx context.Context, email string, password string) (string, *UserResponse, error) {
+	token, userResponse, err := s.login(ctx, email, password)
+	metrics.RecordLogin(err)
+
+	if err != nil {
+		s.audit.Record(ctx, auditlog.Entry{
+			Action:     auditlog.ActionLoginFailed,
+			TargetType: auditlog.TargetUser,
+			Metadata:   map[string]interface{}{"email": email, "reason": apperror.Code(err)},
+		})
+	} else {
+		entry := auditlog.Target(auditlog.ActionLogin, auditlog.TargetUser, userResponse.ID)
+		entry.ActorID = &userResponse.ID
+		s.audit.Record(ctx, entry)
 	}
 
-	user, err := s.repo.FindByEmail(email)
+	return token, userResponse, err
+}
+
+func (s *service) login(ctx context.Context, email string, password string) (string, *UserResponse, error) {
+	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", nil, errors.New("email atau password salah")
+			return "", nil, ErrInvalidCredentials
 		}
-		return "", nil, err
+		return "", nil, ErrLoginFailed.Wrap(err)
 	}
 
 	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, errors.New("email atau password salah")
+	valid, err := s.hasher.Verify(password, user.Password)
+	if err != nil || !valid {
+		return "", nil, ErrInvalidCredentials
+	}
+
+	if user.IsDisabled() {
+		return "", nil, ErrAccountDisabled
+	}
+
+	// Upgrade hash lama (bcrypt / parameter usang) selagi password plain text tersedia
+	if s.hasher.NeedsRehash(user.Password) {
+		s.rehashPassword(ctx, user, password)
 	}
 
 	// Generate token
 	token, err := s.GenerateToken(user.ID)
 	if err != nil {
-		return "", nil, err
+		return "", nil, ErrLoginFailed.Wrap(err)
 	}
 
 	userResponse := &UserResponse{
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
+		Locale:    user.Locale,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -88,42 +123,45 @@ func (s *service) Login(email string, password string) (string, *UserResponse, e
 }
 
 // Register implements Service.
-func (s *service) Register(username string, email string, password string) (*UserResponse, error) {
-	// Validasi input
-	if username == "" || email == "" || password == "" {
-		return nil, errors.New("username, email, dan password tidak boleh kosong")
-	}
-
+func (s *service) Register(ctx context.Context, username string, email string, password string) (*UserResponse, error) {
 	// Check if user already exists
-	existingUser, err := s.repo.FindEmailOrUsername(email, username)
+	existingUser, err := s.repo.FindEmailOrUsername(ctx, email, username)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, err
+		return nil, ErrRegisterFailed.Wrap(err)
 	}
 	if existingUser != nil {
-		return nil, errors.New("email atau username sudah terdaftar")
+		return nil, ErrUserAlreadyExists
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Hash(password)
 	if err != nil {
-		return nil, err
+		return nil, ErrRegisterFailed.Wrap(err)
 	}
 
 	// Create user
 	user := &User{
 		Username: username,
 		Email:    email,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
+		Role:     RoleUser,
 	}
 
-	if err := s.repo.Register(user); err != nil {
-		return nil, err
+	if err := s.repo.Register(ctx, user); err != nil {
+		return nil, ErrRegisterFailed.Wrap(err)
 	}
 
+	entry := auditlog.Target(auditlog.ActionRegister, auditlog.TargetUser, user.ID)
+	entry.ActorID = &user.ID
+	entry.Changes = auditlog.Diff(nil, map[string]interface{}{"username": user.Username, "email": user.Email, "role": user.Role})
+	s.audit.Record(ctx, entry)
+
 	userResponse := &UserResponse{
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
+		Locale:    user.Locale,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -131,8 +169,23 @@ func (s *service) Register(username string, email string, password string) (*Use
 	return userResponse, nil
 }
 
-func NewService(repo Repository, cfg *config.Config) Service {
-	return &service{repo: repo, cfg: cfg}
+// rehashPassword menyimpan hash baru untuk user
+// Kegagalan tidak menggagalkan login, hash akan dicoba di-upgrade lagi pada login berikutnya
+func (s *service) rehashPassword(ctx context.Context, user *User, password string) {
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		slog.WarnContext(ctx, "password rehash failed", slog.Any("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+	if err := s.repo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		slog.WarnContext(ctx, "saving rehashed password failed", slog.Any("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+	user.Password = hashedPassword
+}
+
+func NewService(repo Repository, cfg *config.Config, hasher security.PasswordHasher, audit auditlog.Recorder) Service {
+	return withTracing(&service{repo: repo, cfg: cfg, hasher: hasher, audit: audit})
 }
 
 func (s *service) GetTokenExpiration() time.Duration {
@@ -141,4 +194,4 @@ func (s *service) GetTokenExpiration() time.Duration {
 		return 7 * 24 * time.Hour // default 7 days
 	}
 	return duration
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+// Email dan password sengaja tidak dicatat sebagai atribut span
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// Register implements Service.
+func (t *tracedService) Register(ctx context.Context, username, email, password string) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "auth.Register")
+	res, err := t.next.Register(ctx, username, email, password)
+	tracing.End(span, err)
+	return res, err
+}
+
+// Login implements Service.
+func (t *tracedService) Login(ctx context.Context, email, password string) (string, *UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "auth.Login")
+	token, res, err := t.next.Login(ctx, email, password)
+	tracing.End(span, err)
+	return token, res, err
+}
+
+// GenerateToken implements Service.
+func (t *tracedService) GenerateToken(userID uint) (string, error) {
+	return t.next.GenerateToken(userID)
+}
@@ -0,0 +1,334 @@
+package board
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/internal/task"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Create board
+// @Description Buat board kanban, tanpa columns board dibuat dengan kolom default To Do, In Progress dan Done
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param data body CreateRequest true "Board data"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/boards [post]
+func (ctrl *Controller) CreateBoard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req CreateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	board, err := ctrl.service.CreateBoard(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "BOARD_CREATED", fiber.Map{
+		"board": board,
+	})
+}
+
+// @Summary List boards
+// @Description Semua board milik user tanpa kolom dan card, diurutkan berdasarkan nama
+// @Tags Boards
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/boards [get]
+func (ctrl *Controller) GetBoards(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boards, err := ctrl.service.GetBoards(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "BOARDS_RETRIEVED", fiber.Map{
+		"boards": boards,
+	})
+}
+
+// @Summary Get board
+// @Description Board beserta semua kolom dan card-nya (diurutkan berdasarkan posisi), task yang diarsipkan tidak ikut
+// @Tags Boards
+// @Produce json
+// @Param id path int true "Board ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/boards/{id} [get]
+func (ctrl *Controller) GetBoard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+
+	board, err := ctrl.service.GetBoard(c.UserContext(), user.ID, uint(boardID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "BOARD_RETRIEVED", fiber.Map{
+		"board": board,
+	})
+}
+
+// @Summary Rename board
+// @Description Ubah nama board
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param data body UpdateRequest true "Board data"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/boards/{id} [put]
+func (ctrl *Controller) UpdateBoard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+
+	var req UpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	board, err := ctrl.service.UpdateBoard(c.UserContext(), user.ID, uint(boardID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "BOARD_UPDATED", fiber.Map{
+		"board": board,
+	})
+}
+
+// @Summary Delete board
+// @Description Hapus board beserta kolomnya, task di board tidak ikut dihapus melainkan dikeluarkan dari board
+// @Tags Boards
+// @Produce json
+// @Param id path int true "Board ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/boards/{id} [delete]
+func (ctrl *Controller) DeleteBoard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+
+	if err := ctrl.service.DeleteBoard(c.UserContext(), user.ID, uint(boardID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "BOARD_DELETED", fiber.Map{})
+}
+
+// @Summary Add column
+// @Description Tambah kolom di paling kanan board. Status open/completed mengubah status task yang dipindahkan ke kolom, wipLimit 0 = tanpa batas
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param data body ColumnRequest true "Column data"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/boards/{id}/columns [post]
+func (ctrl *Controller) CreateColumn(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+
+	var req ColumnRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	board, err := ctrl.service.CreateColumn(c.UserContext(), user.ID, uint(boardID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "COLUMN_CREATED", fiber.Map{
+		"board": board,
+	})
+}
+
+// @Summary Update column
+// @Description Ubah nama, status, batas WIP atau urutan kolom (position dimulai dari 0)
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param columnId path int true "Column ID"
+// @Param data body UpdateColumnRequest true "Column data"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/boards/{id}/columns/{columnId} [put]
+func (ctrl *Controller) UpdateColumn(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+	columnID, err := strconv.ParseUint(c.Params("columnId"), 10, 32)
+	if err != nil {
+		return ErrInvalidColumnID
+	}
+
+	var req UpdateColumnRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	board, err := ctrl.service.UpdateColumn(c.UserContext(), user.ID, uint(boardID), uint(columnID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "COLUMN_UPDATED", fiber.Map{
+		"board": board,
+	})
+}
+
+// @Summary Delete column
+// @Description Hapus kolom, task di kolom tidak ikut dihapus melainkan dikeluarkan dari board
+// @Tags Boards
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param columnId path int true "Column ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/boards/{id}/columns/{columnId} [delete]
+func (ctrl *Controller) DeleteColumn(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+	columnID, err := strconv.ParseUint(c.Params("columnId"), 10, 32)
+	if err != nil {
+		return ErrInvalidColumnID
+	}
+
+	if err := ctrl.service.DeleteColumn(c.UserContext(), user.ID, uint(boardID), uint(columnID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "COLUMN_DELETED", fiber.Map{})
+}
+
+// @Summary Move card
+// @Description Taruh task di kolom board, tepat setelah afterId dan/atau tepat sebelum beforeId (tanpa anchor: di bawah card terakhir).
+// @Description Kolom, posisi dan status task (mengikuti status kolom) diubah sekaligus. Gagal dengan 409 jika batas WIP kolom sudah tercapai.
+// @Tags Boards
+// @Accept json
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param taskId path int true "Task ID"
+// @Param data body MoveCardRequest true "Target column"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/boards/{id}/cards/{taskId}/move [post]
+func (ctrl *Controller) MoveCard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+	taskID, err := strconv.ParseUint(c.Params("taskId"), 10, 32)
+	if err != nil {
+		return task.ErrInvalidTaskID
+	}
+
+	var req MoveCardRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	card, err := ctrl.service.MoveCard(c.UserContext(), user.ID, uint(boardID), uint(taskID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CARD_MOVED", fiber.Map{
+		"task": card,
+	})
+}
+
+// @Summary Remove card
+// @Description Keluarkan task dari board, task tidak dihapus
+// @Tags Boards
+// @Produce json
+// @Param id path int true "Board ID"
+// @Param taskId path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/boards/{id}/cards/{taskId} [delete]
+func (ctrl *Controller) RemoveCard(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	boardID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidBoardID
+	}
+	taskID, err := strconv.ParseUint(c.Params("taskId"), 10, 32)
+	if err != nil {
+		return task.ErrInvalidTaskID
+	}
+
+	if err := ctrl.service.RemoveCard(c.UserContext(), user.ID, uint(boardID), uint(taskID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CARD_REMOVED", fiber.Map{})
+}
@@ -0,0 +1,17 @@
+package board
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul board
+var (
+	ErrBoardNotFound     = apperror.NotFound("BOARD_NOT_FOUND", "board not found")
+	ErrBoardForbidden    = apperror.Forbidden("BOARD_FORBIDDEN", "unauthorized to access this board")
+	ErrInvalidBoardID    = apperror.BadRequest("INVALID_BOARD_ID", "Invalid board ID")
+	ErrInvalidColumnID   = apperror.BadRequest("INVALID_COLUMN_ID", "Invalid column ID")
+	ErrColumnNotFound    = apperror.NotFound("COLUMN_NOT_FOUND", "column not found on this board")
+	ErrCardNotFound      = apperror.NotFound("CARD_NOT_FOUND", "task is not on this board")
+	ErrBoardCreateFailed = apperror.Internal("BOARD_CREATE_FAILED", "failed to create board", nil)
+	ErrBoardFetchFailed  = apperror.Internal("BOARD_FETCH_FAILED", "failed to retrieve board", nil)
+	ErrBoardUpdateFailed = apperror.Internal("BOARD_UPDATE_FAILED", "failed to update board", nil)
+	ErrBoardDeleteFailed = apperror.Internal("BOARD_DELETE_FAILED", "failed to delete board", nil)
+)
@@ -0,0 +1,91 @@
+package board
+
+import (
+	"rest-api/internal/task"
+	"time"
+)
+
+// Board berisi kolom berurutan, card di board adalah task milik user yang ditaruh di salah satu kolom
+type Board struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null" json:"userId"`
+	Name      string    `gorm:"size:191;not null" json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	Columns []Column `gorm:"foreignKey:BoardID"` // diurutkan berdasarkan Position
+}
+
+// Column adalah satu kolom board, Status menentukan status task yang dipindahkan ke kolom ini
+type Column struct {
+	ID        uint   `gorm:"primaryKey"`
+	BoardID   uint   `gorm:"not null"`
+	Name      string `gorm:"size:191;not null"`
+	Position  int    `gorm:"not null"`
+	Status    string `gorm:"size:20;not null;default:none"`
+	WIPLimit  int    `gorm:"column:wip_limit;not null"` // 0 = tanpa batas
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Column) TableName() string {
+	return "board_columns"
+}
+
+// Status kolom
+const (
+	StatusNone      = "none"      // kolom bebas, status task tidak berubah
+	StatusOpen      = "open"      // task dibuka kembali saat dipindahkan ke kolom
+	StatusCompleted = "completed" // task ditandai selesai saat dipindahkan ke kolom
+)
+
+// Request DTOs
+type CreateRequest struct {
+	Name    string          `json:"name" validate:"required,max=100"`
+	Columns []ColumnRequest `json:"columns" validate:"max=20,dive"` // kosong = kolom default (To Do, In Progress, Done)
+}
+
+type UpdateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type ColumnRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Status   string `json:"status" validate:"omitempty,oneof=none open completed"`
+	WIPLimit int    `json:"wipLimit" validate:"min=0,max=1000"`
+}
+
+// Field pointer bernilai nil jika tidak dikirim
+type UpdateColumnRequest struct {
+	Name     *string `json:"name" validate:"omitnil,min=1,max=100"`
+	Status   *string `json:"status" validate:"omitnil,oneof=none open completed"`
+	WIPLimit *int    `json:"wipLimit" validate:"omitnil,min=0,max=1000"`
+	Position *int    `json:"position" validate:"omitnil,min=0"` // urutan baru kolom, dimulai dari 0
+}
+
+// MoveCardRequest memindahkan task ke kolom, tepat setelah afterId dan/atau tepat sebelum beforeId
+// Tanpa anchor, task ditaruh di bawah card terakhir kolom
+type MoveCardRequest struct {
+	ColumnID uint  `json:"columnId" validate:"required"`
+	AfterID  *uint `json:"afterId"`
+	BeforeID *uint `json:"beforeId"`
+}
+
+// Response DTOs
+type Response struct {
+	ID        uint             `json:"id"`
+	Name      string           `json:"name"`
+	Columns   []ColumnResponse `json:"columns,omitempty"` // hanya diisi di detail board
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	UserID    uint             `json:"userId"`
+}
+
+type ColumnResponse struct {
+	ID       uint            `json:"id"`
+	Name     string          `json:"name"`
+	Position int             `json:"position"`
+	Status   string          `json:"status"`
+	WIPLimit int             `json:"wipLimit"`
+	Cards    []task.Response `json:"cards"`
+}
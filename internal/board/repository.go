@@ -0,0 +1,112 @@
+package board
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, board *Board) error
+	Update(ctx context.Context, board *Board) error
+	FindByID(ctx context.Context, id uint) (*Board, error)
+	FindAllByUserID(ctx context.Context, userID uint) ([]Board, error)
+	Delete(ctx context.Context, board *Board) error
+	CreateColumn(ctx context.Context, column *Column) error
+	UpdateColumns(ctx context.Context, columns []Column) error
+	DeleteColumn(ctx context.Context, column *Column) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+// Kolom board ikut disimpan lewat asosiasi
+func (r *repository) Create(ctx context.Context, board *Board) error {
+	return r.db.WithContext(ctx).Create(board).Error
+}
+
+// Update implements Repository.
+// Hanya baris board yang disimpan, gunakan UpdateColumns untuk kolom
+func (r *repository) Update(ctx context.Context, board *Board) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(board).Error
+}
+
+// FindByID implements Repository.
+func (r *repository) FindByID(ctx context.Context, id uint) (*Board, error) {
+	var board Board
+	if err := r.db.WithContext(ctx).
+		Preload("Columns", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc").Order("id asc")
+		}).
+		First(&board, id).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// FindAllByUserID implements Repository.
+func (r *repository) FindAllByUserID(ctx context.Context, userID uint) ([]Board, error) {
+	var boards []Board
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name asc").
+		Find(&boards).Error; err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// Delete implements Repository.
+// Task di kolom board (termasuk yang ada di trash) dikeluarkan dari board, bukan ikut dihapus
+func (r *repository) Delete(ctx context.Context, board *Board) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		columns := tx.Model(&Column{}).Select("id").Where("board_id = ?", board.ID)
+		if err := tx.Table("tasks").
+			Where("column_id IN (?)", columns).
+			Update("column_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("board_id = ?", board.ID).Delete(&Column{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(board).Error
+	})
+}
+
+// CreateColumn implements Repository.
+func (r *repository) CreateColumn(ctx context.Context, column *Column) error {
+	return r.db.WithContext(ctx).Create(column).Error
+}
+
+// UpdateColumns implements Repository.
+// Semua kolom disimpan dalam satu transaksi, dipakai saat urutan kolom berubah
+func (r *repository) UpdateColumns(ctx context.Context, columns []Column) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range columns {
+			if err := tx.Save(&columns[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteColumn implements Repository.
+// Task di kolom dikeluarkan dari board
+func (r *repository) DeleteColumn(ctx context.Context, column *Column) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("tasks").
+			Where("column_id = ?", column.ID).
+			Update("column_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(column).Error
+	})
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
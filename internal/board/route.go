@@ -0,0 +1,23 @@
+package board
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	boards := app.Group("/api/boards")
+
+	boards.Post("/", middlewares.Auth(cfg), ctrl.CreateBoard)
+	boards.Get("/", middlewares.Auth(cfg), ctrl.GetBoards)
+	boards.Get("/:id", middlewares.Auth(cfg), ctrl.GetBoard)
+	boards.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateBoard)
+	boards.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteBoard)
+	boards.Post("/:id/columns", middlewares.Auth(cfg), ctrl.CreateColumn)
+	boards.Put("/:id/columns/:columnId", middlewares.Auth(cfg), ctrl.UpdateColumn)
+	boards.Delete("/:id/columns/:columnId", middlewares.Auth(cfg), ctrl.DeleteColumn)
+	boards.Post("/:id/cards/:taskId/move", middlewares.Auth(cfg), ctrl.MoveCard)
+	boards.Delete("/:id/cards/:taskId", middlewares.Auth(cfg), ctrl.RemoveCard)
+}
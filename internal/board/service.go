@@ -0,0 +1,366 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"rest-api/internal/task"
+	"rest-api/pkg/auditlog"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	CreateBoard(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetBoards(ctx context.Context, userID uint) ([]Response, error)
+	GetBoard(ctx context.Context, userID, id uint) (*Response, error)
+	UpdateBoard(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error)
+	DeleteBoard(ctx context.Context, userID, id uint) error
+	CreateColumn(ctx context.Context, userID, boardID uint, req *ColumnRequest) (*Response, error)
+	UpdateColumn(ctx context.Context, userID, boardID, columnID uint, req *UpdateColumnRequest) (*Response, error)
+	DeleteColumn(ctx context.Context, userID, boardID, columnID uint) error
+	MoveCard(ctx context.Context, userID, boardID, taskID uint, req *MoveCardRequest) (*task.Response, error)
+	RemoveCard(ctx context.Context, userID, boardID, taskID uint) error
+}
+
+type service struct {
+	repo  Repository
+	tasks task.Service
+	audit auditlog.Recorder
+}
+
+// defaultColumns dipakai jika board dibuat tanpa kolom
+var defaultColumns = []ColumnRequest{
+	{Name: "To Do", Status: StatusOpen},
+	{Name: "In Progress", Status: StatusOpen},
+	{Name: "Done", Status: StatusCompleted},
+}
+
+// CreateBoard implements Service.
+func (s *service) CreateBoard(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	columns := req.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	board := &Board{UserID: userID, Name: strings.TrimSpace(req.Name)}
+	for i, column := range columns {
+		board.Columns = append(board.Columns, newColumn(&column, i))
+	}
+	if err := s.repo.Create(ctx, board); err != nil {
+		return nil, ErrBoardCreateFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionBoardCreate, auditlog.TargetBoard, board.ID)
+	entry.Changes = auditlog.Diff(nil, map[string]interface{}{"name": board.Name})
+	s.audit.Record(ctx, entry)
+
+	return s.toDetail(ctx, board)
+}
+
+// GetBoards implements Service.
+// Kolom dan card tidak ikut, gunakan GetBoard untuk isi board
+func (s *service) GetBoards(ctx context.Context, userID uint) ([]Response, error) {
+	boards, err := s.repo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrBoardFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(boards))
+	for i := range boards {
+		responses[i] = *toResponse(&boards[i])
+	}
+
+	return responses, nil
+}
+
+// GetBoard implements Service.
+// Mengembalikan semua kolom beserta card-nya dalam satu response
+func (s *service) GetBoard(ctx context.Context, userID, id uint) (*Response, error) {
+	board, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toDetail(ctx, board)
+}
+
+// UpdateBoard implements Service.
+func (s *service) UpdateBoard(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	board, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := board.Name
+	board.Name = strings.TrimSpace(req.Name)
+	if board.Name != before {
+		if err := s.repo.Update(ctx, board); err != nil {
+			return nil, ErrBoardUpdateFailed.Wrap(err)
+		}
+
+		entry := auditlog.Target(auditlog.ActionBoardUpdate, auditlog.TargetBoard, board.ID)
+		entry.Changes = auditlog.Changes{"name": {From: before, To: board.Name}}
+		s.audit.Record(ctx, entry)
+	}
+
+	return s.toDetail(ctx, board)
+}
+
+// DeleteBoard implements Service.
+// Task di board tidak ikut dihapus, hanya dikeluarkan dari board
+func (s *service) DeleteBoard(ctx context.Context, userID, id uint) error {
+	board, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, board); err != nil {
+		return ErrBoardDeleteFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionBoardDelete, auditlog.TargetBoard, board.ID)
+	entry.Changes = auditlog.Diff(map[string]interface{}{"name": board.Name}, nil)
+	s.audit.Record(ctx, entry)
+
+	return nil
+}
+
+// CreateColumn implements Service.
+// Kolom baru ditaruh paling kanan
+func (s *service) CreateColumn(ctx context.Context, userID, boardID uint, req *ColumnRequest) (*Response, error) {
+	board, err := s.findOwned(ctx, userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := 0
+	if n := len(board.Columns); n > 0 {
+		position = board.Columns[n-1].Position + 1
+	}
+	column := newColumn(req, position)
+	column.BoardID = board.ID
+	if err := s.repo.CreateColumn(ctx, &column); err != nil {
+		return nil, ErrBoardUpdateFailed.Wrap(err)
+	}
+	board.Columns = append(board.Columns, column)
+
+	s.recordColumn(ctx, board, column.ID, auditlog.Diff(nil, columnSnapshot(&column)))
+
+	return s.toDetail(ctx, board)
+}
+
+// UpdateColumn implements Service.
+// Jika position diisi, kolom dipindahkan ke urutan tersebut dan posisi semua kolom board disimpan ulang
+// Perubahan status tidak mengubah task yang sudah ada di kolom, hanya task yang dipindahkan setelahnya
+func (s *service) UpdateColumn(ctx context.Context, userID, boardID, columnID uint, req *UpdateColumnRequest) (*Response, error) {
+	board, err := s.findOwned(ctx, userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	index := columnIndex(board, columnID)
+	if index < 0 {
+		return nil, ErrColumnNotFound
+	}
+
+	column := &board.Columns[index]
+	before := columnSnapshot(column)
+	if req.Name != nil {
+		column.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Status != nil {
+		column.Status = *req.Status
+	}
+	if req.WIPLimit != nil {
+		column.WIPLimit = *req.WIPLimit
+	}
+	if req.Position != nil {
+		moved := *column
+		columns := slices.Delete(board.Columns, index, index+1)
+		board.Columns = slices.Insert(columns, min(*req.Position, len(columns)), moved)
+	}
+	for i := range board.Columns {
+		board.Columns[i].Position = i
+	}
+
+	changes := auditlog.Diff(before, columnSnapshot(&board.Columns[columnIndex(board, columnID)]))
+	if len(changes) == 0 {
+		return s.toDetail(ctx, board)
+	}
+	if err := s.repo.UpdateColumns(ctx, board.Columns); err != nil {
+		return nil, ErrBoardUpdateFailed.Wrap(err)
+	}
+	s.recordColumn(ctx, board, columnID, changes)
+
+	return s.toDetail(ctx, board)
+}
+
+// DeleteColumn implements Service.
+// Task di kolom tidak ikut dihapus, hanya dikeluarkan dari board
+func (s *service) DeleteColumn(ctx context.Context, userID, boardID, columnID uint) error {
+	board, err := s.findOwned(ctx, userID, boardID)
+	if err != nil {
+		return err
+	}
+	index := columnIndex(board, columnID)
+	if index < 0 {
+		return ErrColumnNotFound
+	}
+
+	column := board.Columns[index]
+	if err := s.repo.DeleteColumn(ctx, &column); err != nil {
+		return ErrBoardUpdateFailed.Wrap(err)
+	}
+	s.recordColumn(ctx, board, column.ID, auditlog.Diff(columnSnapshot(&column), nil))
+
+	return nil
+}
+
+// MoveCard implements Service.
+// Kolom dan posisi task diubah sekaligus, status task mengikuti status kolom
+// Batas WIP kolom dicek saat task masuk dari luar kolom
+func (s *service) MoveCard(ctx context.Context, userID, boardID, taskID uint, req *MoveCardRequest) (*task.Response, error) {
+	board, err := s.findOwned(ctx, userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	index := columnIndex(board, req.ColumnID)
+	if index < 0 {
+		return nil, ErrColumnNotFound
+	}
+
+	column := board.Columns[index]
+	move := &task.CardMove{
+		ColumnID: column.ID,
+		AfterID:  req.AfterID,
+		BeforeID: req.BeforeID,
+		WIPLimit: column.WIPLimit,
+	}
+	if column.Status != StatusNone {
+		completed := column.Status == StatusCompleted
+		move.IsCompleted = &completed
+	}
+
+	return s.tasks.MoveCard(ctx, userID, taskID, move)
+}
+
+// RemoveCard implements Service.
+// Task dikeluarkan dari board tanpa mengubah field lainnya
+func (s *service) RemoveCard(ctx context.Context, userID, boardID, taskID uint) error {
+	board, err := s.findOwned(ctx, userID, boardID)
+	if err != nil {
+		return err
+	}
+
+	card, err := s.tasks.GetTaskByID(ctx, userID, taskID)
+	if err != nil {
+		return err
+	}
+	if card.ColumnID == nil || columnIndex(board, *card.ColumnID) < 0 {
+		return ErrCardNotFound
+	}
+
+	_, err = s.tasks.MoveCard(ctx, userID, taskID, &task.CardMove{})
+	return err
+}
+
+// findOwned mengambil board beserta kolomnya dan memastikan board milik userID
+func (s *service) findOwned(ctx context.Context, userID, id uint) (*Board, error) {
+	board, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, ErrBoardFetchFailed.Wrap(err)
+	}
+
+	if board.UserID != userID {
+		return nil, ErrBoardForbidden
+	}
+
+	return board, nil
+}
+
+// recordColumn mencatat perubahan kolom sebagai board.update
+func (s *service) recordColumn(ctx context.Context, board *Board, columnID uint, changes auditlog.Changes) {
+	entry := auditlog.Target(auditlog.ActionBoardUpdate, auditlog.TargetBoard, board.ID)
+	entry.Changes = changes
+	entry.Metadata = map[string]interface{}{"columnId": columnID}
+	s.audit.Record(ctx, entry)
+}
+
+// toDetail mengubah board menjadi response lengkap dengan kolom dan card
+func (s *service) toDetail(ctx context.Context, board *Board) (*Response, error) {
+	response := toResponse(board)
+	response.Columns = make([]ColumnResponse, len(board.Columns))
+
+	ids := make([]uint, len(board.Columns))
+	for i, column := range board.Columns {
+		ids[i] = column.ID
+		response.Columns[i] = ColumnResponse{
+			ID:       column.ID,
+			Name:     column.Name,
+			Position: column.Position,
+			Status:   column.Status,
+			WIPLimit: column.WIPLimit,
+			Cards:    []task.Response{},
+		}
+	}
+
+	cards, err := s.tasks.GetCards(ctx, board.UserID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		if i := slices.Index(ids, *card.ColumnID); i >= 0 {
+			response.Columns[i].Cards = append(response.Columns[i].Cards, card)
+		}
+	}
+
+	return response, nil
+}
+
+// newColumn membuat kolom dari request, status kosong berarti StatusNone
+func newColumn(req *ColumnRequest, position int) Column {
+	column := Column{
+		Name:     strings.TrimSpace(req.Name),
+		Position: position,
+		Status:   req.Status,
+		WIPLimit: req.WIPLimit,
+	}
+	if column.Status == "" {
+		column.Status = StatusNone
+	}
+	return column
+}
+
+// columnIndex mengembalikan index kolom di board.Columns, -1 jika kolom bukan milik board
+func columnIndex(board *Board, columnID uint) int {
+	return slices.IndexFunc(board.Columns, func(column Column) bool { return column.ID == columnID })
+}
+
+// columnSnapshot mengambil field kolom yang dicatat di audit log
+func columnSnapshot(column *Column) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     column.Name,
+		"position": column.Position,
+		"status":   column.Status,
+		"wipLimit": column.WIPLimit,
+	}
+}
+
+// toResponse mengubah model Board menjadi response DTO tanpa kolom
+func toResponse(board *Board) *Response {
+	return &Response{
+		ID:        board.ID,
+		Name:      board.Name,
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+		UserID:    board.UserID,
+	}
+}
+
+func NewService(repo Repository, tasks task.Service, audit auditlog.Recorder) Service {
+	return withTracing(&service{repo: repo, tasks: tasks, audit: audit})
+}
@@ -0,0 +1,98 @@
+package board
+
+import (
+	"context"
+	"rest-api/internal/task"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// CreateBoard implements Service.
+func (t *tracedService) CreateBoard(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "board.CreateBoard", attribute.Int("user.id", int(userID)))
+	res, err := t.next.CreateBoard(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetBoards implements Service.
+func (t *tracedService) GetBoards(ctx context.Context, userID uint) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "board.GetBoards", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetBoards(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetBoard implements Service.
+func (t *tracedService) GetBoard(ctx context.Context, userID, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "board.GetBoard", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(id)))
+	res, err := t.next.GetBoard(ctx, userID, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateBoard implements Service.
+func (t *tracedService) UpdateBoard(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "board.UpdateBoard", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(id)))
+	res, err := t.next.UpdateBoard(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteBoard implements Service.
+func (t *tracedService) DeleteBoard(ctx context.Context, userID, id uint) error {
+	ctx, span := tracing.Start(ctx, "board.DeleteBoard", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(id)))
+	err := t.next.DeleteBoard(ctx, userID, id)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateColumn implements Service.
+func (t *tracedService) CreateColumn(ctx context.Context, userID, boardID uint, req *ColumnRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "board.CreateColumn", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(boardID)))
+	res, err := t.next.CreateColumn(ctx, userID, boardID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateColumn implements Service.
+func (t *tracedService) UpdateColumn(ctx context.Context, userID, boardID, columnID uint, req *UpdateColumnRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "board.UpdateColumn", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(boardID)), attribute.Int("board.column_id", int(columnID)))
+	res, err := t.next.UpdateColumn(ctx, userID, boardID, columnID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteColumn implements Service.
+func (t *tracedService) DeleteColumn(ctx context.Context, userID, boardID, columnID uint) error {
+	ctx, span := tracing.Start(ctx, "board.DeleteColumn", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(boardID)), attribute.Int("board.column_id", int(columnID)))
+	err := t.next.DeleteColumn(ctx, userID, boardID, columnID)
+	tracing.End(span, err)
+	return err
+}
+
+// MoveCard implements Service.
+func (t *tracedService) MoveCard(ctx context.Context, userID, boardID, taskID uint, req *MoveCardRequest) (*task.Response, error) {
+	ctx, span := tracing.Start(ctx, "board.MoveCard", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(boardID)), attribute.Int("task.id", int(taskID)), attribute.Int("board.column_id", int(req.ColumnID)))
+	res, err := t.next.MoveCard(ctx, userID, boardID, taskID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// RemoveCard implements Service.
+func (t *tracedService) RemoveCard(ctx context.Context, userID, boardID, taskID uint) error {
+	ctx, span := tracing.Start(ctx, "board.RemoveCard", attribute.Int("user.id", int(userID)), attribute.Int("board.id", int(boardID)), attribute.Int("task.id", int(taskID)))
+	err := t.next.RemoveCard(ctx, userID, boardID, taskID)
+	tracing.End(span, err)
+	return err
+}
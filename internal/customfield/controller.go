@@ -0,0 +1,161 @@
+package customfield
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Create custom field
+// @Description Buat definisi custom field (text, number, date, select, checkbox, url). Key dipakai di customFields task dan filter list task, unik per user. projectId opsional membatasi field ke task di project tersebut
+// @Tags Custom Fields
+// @Accept json
+// @Produce json
+// @Param data body CreateRequest true "Custom field"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/custom-fields [post]
+func (ctrl *Controller) CreateField(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req CreateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	field, err := ctrl.service.CreateField(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "CUSTOM_FIELD_CREATED", fiber.Map{
+		"field": field,
+	})
+}
+
+// @Summary List custom fields
+// @Description Semua custom field milik user, projectId opsional untuk hanya menampilkan field yang berlaku di project tersebut
+// @Tags Custom Fields
+// @Produce json
+// @Param query query ListQuery false "Filter"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/custom-fields [get]
+func (ctrl *Controller) GetFields(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	fields, err := ctrl.service.GetFields(c.UserContext(), user.ID, &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CUSTOM_FIELDS_RETRIEVED", fiber.Map{
+		"fields": fields,
+	})
+}
+
+// @Summary Get custom field detail
+// @Description Get detail of a custom field by ID
+// @Tags Custom Fields
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/custom-fields/{id} [get]
+func (ctrl *Controller) GetFieldByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	fieldID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidFieldID
+	}
+
+	field, err := ctrl.service.GetFieldByID(c.UserContext(), user.ID, uint(fieldID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CUSTOM_FIELD_RETRIEVED", fiber.Map{
+		"field": field,
+	})
+}
+
+// @Summary Update custom field
+// @Description Ubah nama atau pilihan field select. Nilai task yang memakai pilihan yang dihapus ikut dihapus
+// @Tags Custom Fields
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Param data body UpdateRequest true "Custom field"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/custom-fields/{id} [put]
+func (ctrl *Controller) UpdateField(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	fieldID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidFieldID
+	}
+
+	var req UpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	field, err := ctrl.service.UpdateField(c.UserContext(), user.ID, uint(fieldID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CUSTOM_FIELD_UPDATED", fiber.Map{
+		"field": field,
+	})
+}
+
+// @Summary Delete custom field
+// @Description Hapus custom field beserta nilainya di semua task
+// @Tags Custom Fields
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/custom-fields/{id} [delete]
+func (ctrl *Controller) DeleteField(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	fieldID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidFieldID
+	}
+
+	if err := ctrl.service.DeleteField(c.UserContext(), user.ID, uint(fieldID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "CUSTOM_FIELD_DELETED", fiber.Map{})
+}
@@ -0,0 +1,20 @@
+package customfield
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul custom field
+var (
+	ErrFieldNotFound     = apperror.NotFound("CUSTOM_FIELD_NOT_FOUND", "custom field not found")
+	ErrFieldForbidden    = apperror.Forbidden("CUSTOM_FIELD_FORBIDDEN", "unauthorized to access this custom field")
+	ErrInvalidFieldID    = apperror.BadRequest("INVALID_CUSTOM_FIELD_ID", "Invalid custom field ID")
+	ErrInvalidKey        = apperror.BadRequest("INVALID_CUSTOM_FIELD_KEY", "key must start with a letter and contain only lowercase letters, digits and underscores")
+	ErrKeyTaken          = apperror.Conflict("CUSTOM_FIELD_KEY_TAKEN", "custom field key already exists")
+	ErrOptionsNotAllowed = apperror.BadRequest("CUSTOM_FIELD_OPTIONS_NOT_ALLOWED", "options are only allowed for select fields")
+	ErrProjectNotFound   = apperror.NotFound("PROJECT_NOT_FOUND", "project not found")
+	ErrUnknownField      = apperror.BadRequest("UNKNOWN_CUSTOM_FIELD", "custom field is not available for this task")
+	ErrInvalidValue      = apperror.BadRequest("INVALID_CUSTOM_FIELD_VALUE", "invalid custom field value")
+	ErrFieldCreateFailed = apperror.Internal("CUSTOM_FIELD_CREATE_FAILED", "failed to create custom field", nil)
+	ErrFieldFetchFailed  = apperror.Internal("CUSTOM_FIELD_FETCH_FAILED", "failed to retrieve custom field", nil)
+	ErrFieldUpdateFailed = apperror.Internal("CUSTOM_FIELD_UPDATE_FAILED", "failed to update custom field", nil)
+	ErrFieldDeleteFailed = apperror.Internal("CUSTOM_FIELD_DELETE_FAILED", "failed to delete custom field", nil)
+)
@@ -0,0 +1,64 @@
+package customfield
+
+import "time"
+
+// Field adalah definisi custom field milik user
+// ProjectID diisi jika field hanya berlaku untuk task di project tersebut, nil = semua task user
+type Field struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"not null"`
+	ProjectID *uint
+	Key       string   `gorm:"column:field_key;size:50;not null"` // dipakai di request dan query task, unik per user
+	Name      string   `gorm:"size:100;not null"`
+	Type      string   `gorm:"size:20;not null"`
+	Options   []string `gorm:"type:text;serializer:json"` // pilihan untuk type select
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Field) TableName() string {
+	return "custom_fields"
+}
+
+// Tipe custom field
+const (
+	TypeText     = "text"
+	TypeNumber   = "number"
+	TypeDate     = "date" // format 2006-01-02
+	TypeSelect   = "select"
+	TypeCheckbox = "checkbox"
+	TypeURL      = "url"
+)
+
+// Query DTOs
+type ListQuery struct {
+	ProjectID *uint `json:"projectId" query:"projectId"` // hanya field yang berlaku untuk task di project ini
+}
+
+// Request DTOs
+type CreateRequest struct {
+	Key       string   `json:"key" validate:"required,max=50"`
+	Name      string   `json:"name" validate:"required,max=100"`
+	Type      string   `json:"type" validate:"required,oneof=text number date select checkbox url"`
+	Options   []string `json:"options" validate:"required_if=Type select,max=50,dive,min=1,max=100"`
+	ProjectID *uint    `json:"projectId"`
+}
+
+// Key, type dan project tidak bisa diubah setelah field dibuat
+type UpdateRequest struct {
+	Name    *string   `json:"name" validate:"omitnil,min=1,max=100"`
+	Options *[]string `json:"options" validate:"omitnil,min=1,max=50,dive,min=1,max=100"` // hanya untuk type select
+}
+
+// Response DTOs
+type Response struct {
+	ID        uint      `json:"id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	ProjectID *uint     `json:"projectId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UserID    uint      `json:"userId"`
+}
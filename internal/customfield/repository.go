@@ -0,0 +1,100 @@
+package customfield
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, field *Field) error
+	Update(ctx context.Context, field *Field, removedOptions []string) error
+	FindByID(ctx context.Context, id uint) (*Field, error)
+	FindAllByUserID(ctx context.Context, userID uint, projectID *uint) ([]Field, error)
+	ExistsByKey(ctx context.Context, userID uint, key string) (bool, error)
+	ProjectExists(ctx context.Context, userID, projectID uint) (bool, error)
+	Delete(ctx context.Context, field *Field) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+func (r *repository) Create(ctx context.Context, field *Field) error {
+	return r.db.WithContext(ctx).Create(field).Error
+}
+
+// Update implements Repository.
+// Nilai task yang memakai pilihan di removedOptions ikut dihapus dalam transaksi yang sama
+func (r *repository) Update(ctx context.Context, field *Field, removedOptions []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(field).Error; err != nil {
+			return err
+		}
+		if len(removedOptions) == 0 {
+			return nil
+		}
+		return tx.Table("task_field_values").
+			Where("field_id = ? AND value IN ?", field.ID, removedOptions).
+			Delete(nil).Error
+	})
+}
+
+// FindByID implements Repository.
+func (r *repository) FindByID(ctx context.Context, id uint) (*Field, error) {
+	var field Field
+	if err := r.db.WithContext(ctx).First(&field, id).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// FindAllByUserID implements Repository.
+// Jika projectID tidak nil, hanya field global dan field milik project tersebut yang dikembalikan
+func (r *repository) FindAllByUserID(ctx context.Context, userID uint, projectID *uint) ([]Field, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if projectID != nil {
+		query = query.Where("project_id IS NULL OR project_id = ?", *projectID)
+	}
+
+	var fields []Field
+	if err := query.Order("name asc").Order("id asc").Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// ExistsByKey implements Repository.
+func (r *repository) ExistsByKey(ctx context.Context, userID uint, key string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&Field{}).
+		Where("user_id = ? AND field_key = ?", userID, key).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ProjectExists implements Repository.
+func (r *repository) ProjectExists(ctx context.Context, userID, projectID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Table("projects").
+		Where("id = ? AND user_id = ?", projectID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete implements Repository.
+// Nilai field di semua task ikut terhapus lewat ON DELETE CASCADE
+func (r *repository) Delete(ctx context.Context, field *Field) error {
+	return r.db.WithContext(ctx).Delete(field).Error
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
@@ -0,0 +1,18 @@
+package customfield
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	fields := app.Group("/api/custom-fields")
+
+	fields.Post("/", middlewares.Auth(cfg), ctrl.CreateField)
+	fields.Get("/", middlewares.Auth(cfg), ctrl.GetFields)
+	fields.Get("/:id", middlewares.Auth(cfg), ctrl.GetFieldByID)
+	fields.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateField)
+	fields.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteField)
+}
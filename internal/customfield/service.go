@@ -0,0 +1,228 @@
+package customfield
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"rest-api/pkg/auditlog"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	CreateField(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetFields(ctx context.Context, userID uint, query *ListQuery) ([]Response, error)
+	GetFieldByID(ctx context.Context, userID, id uint) (*Response, error)
+	UpdateField(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error)
+	DeleteField(ctx context.Context, userID, id uint) error
+}
+
+type service struct {
+	repo  Repository
+	audit auditlog.Recorder
+}
+
+// keyPattern adalah format key field: huruf kecil, angka dan underscore, diawali huruf
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CreateField implements Service.
+func (s *service) CreateField(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	key := strings.TrimSpace(req.Key)
+	if !keyPattern.MatchString(key) {
+		return nil, ErrInvalidKey
+	}
+	if req.Type != TypeSelect && len(req.Options) > 0 {
+		return nil, ErrOptionsNotAllowed
+	}
+
+	exists, err := s.repo.ExistsByKey(ctx, userID, key)
+	if err != nil {
+		return nil, ErrFieldFetchFailed.Wrap(err)
+	}
+	if exists {
+		return nil, ErrKeyTaken
+	}
+
+	field := &Field{
+		UserID:  userID,
+		Key:     key,
+		Name:    strings.TrimSpace(req.Name),
+		Type:    req.Type,
+		Options: toOptions(req.Options),
+	}
+	if req.ProjectID != nil && *req.ProjectID != 0 {
+		exists, err := s.repo.ProjectExists(ctx, userID, *req.ProjectID)
+		if err != nil {
+			return nil, ErrFieldFetchFailed.Wrap(err)
+		}
+		if !exists {
+			return nil, ErrProjectNotFound
+		}
+		projectID := *req.ProjectID
+		field.ProjectID = &projectID
+	}
+
+	if err := s.repo.Create(ctx, field); err != nil {
+		return nil, ErrFieldCreateFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionCustomFieldCreate, auditlog.TargetCustomField, field.ID)
+	entry.Changes = auditlog.Diff(nil, snapshot(field))
+	s.audit.Record(ctx, entry)
+
+	return toResponse(field), nil
+}
+
+// GetFields implements Service.
+// Jika query.ProjectID diisi, hanya field yang bisa dipakai task di project tersebut yang dikembalikan
+func (s *service) GetFields(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	fields, err := s.repo.FindAllByUserID(ctx, userID, query.ProjectID)
+	if err != nil {
+		return nil, ErrFieldFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(fields))
+	for i := range fields {
+		responses[i] = *toResponse(&fields[i])
+	}
+
+	return responses, nil
+}
+
+// GetFieldByID implements Service.
+func (s *service) GetFieldByID(ctx context.Context, userID, id uint) (*Response, error) {
+	field, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(field), nil
+}
+
+// UpdateField implements Service.
+// Nilai task yang memakai pilihan yang dihapus dari options ikut dihapus
+func (s *service) UpdateField(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	field, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Options != nil && field.Type != TypeSelect {
+		return nil, ErrOptionsNotAllowed
+	}
+
+	before := snapshot(field)
+	if req.Name != nil {
+		field.Name = strings.TrimSpace(*req.Name)
+	}
+	var removed []string
+	if req.Options != nil {
+		options := toOptions(*req.Options)
+		for _, option := range field.Options {
+			if !slices.Contains(options, option) {
+				removed = append(removed, option)
+			}
+		}
+		field.Options = options
+	}
+
+	changes := auditlog.Diff(before, snapshot(field))
+	if len(changes) == 0 {
+		return toResponse(field), nil
+	}
+	if err := s.repo.Update(ctx, field, removed); err != nil {
+		return nil, ErrFieldUpdateFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionCustomFieldUpdate, auditlog.TargetCustomField, field.ID)
+	entry.Changes = changes
+	s.audit.Record(ctx, entry)
+
+	return toResponse(field), nil
+}
+
+// DeleteField implements Service.
+// Nilai field di semua task ikut dihapus
+func (s *service) DeleteField(ctx context.Context, userID, id uint) error {
+	field, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, field); err != nil {
+		return ErrFieldDeleteFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionCustomFieldDelete, auditlog.TargetCustomField, field.ID)
+	entry.Changes = auditlog.Diff(snapshot(field), nil)
+	s.audit.Record(ctx, entry)
+
+	return nil
+}
+
+// findOwned mengambil field dan memastikan field milik userID
+func (s *service) findOwned(ctx context.Context, userID, id uint) (*Field, error) {
+	field, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFieldNotFound
+		}
+		return nil, ErrFieldFetchFailed.Wrap(err)
+	}
+
+	if field.UserID != userID {
+		return nil, ErrFieldForbidden
+	}
+
+	return field, nil
+}
+
+// snapshot mengambil field definisi yang dicatat di audit log
+func snapshot(field *Field) map[string]interface{} {
+	var projectID interface{}
+	if field.ProjectID != nil {
+		projectID = *field.ProjectID
+	}
+
+	return map[string]interface{}{
+		"key":       field.Key,
+		"name":      field.Name,
+		"type":      field.Type,
+		"options":   slices.Clone(field.Options),
+		"projectId": projectID,
+	}
+}
+
+// toOptions menormalkan pilihan select (trim, unik, urutan dari request dipertahankan)
+func toOptions(values []string) []string {
+	options := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(options, value) {
+			options = append(options, value)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
+
+func toResponse(field *Field) *Response {
+	return &Response{
+		ID:        field.ID,
+		Key:       field.Key,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   field.Options,
+		ProjectID: field.ProjectID,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+		UserID:    field.UserID,
+	}
+}
+
+func NewService(repo Repository, audit auditlog.Recorder) Service {
+	return withTracing(&service{repo: repo, audit: audit})
+}
@@ -0,0 +1,57 @@
+package customfield
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// CreateField implements Service.
+func (t *tracedService) CreateField(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "customfield.CreateField", attribute.Int("user.id", int(userID)))
+	res, err := t.next.CreateField(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetFields implements Service.
+func (t *tracedService) GetFields(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "customfield.GetFields", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetFields(ctx, userID, query)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetFieldByID implements Service.
+func (t *tracedService) GetFieldByID(ctx context.Context, userID, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "customfield.GetFieldByID", attribute.Int("user.id", int(userID)), attribute.Int("custom_field.id", int(id)))
+	res, err := t.next.GetFieldByID(ctx, userID, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateField implements Service.
+func (t *tracedService) UpdateField(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "customfield.UpdateField", attribute.Int("user.id", int(userID)), attribute.Int("custom_field.id", int(id)))
+	res, err := t.next.UpdateField(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteField implements Service.
+func (t *tracedService) DeleteField(ctx context.Context, userID, id uint) error {
+	ctx, span := tracing.Start(ctx, "customfield.DeleteField", attribute.Int("user.id", int(userID)), attribute.Int("custom_field.id", int(id)))
+	err := t.next.DeleteField(ctx, userID, id)
+	tracing.End(span, err)
+	return err
+}
@@ -0,0 +1,118 @@
+package customfield
+
+import (
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxValueLength adalah panjang maksimal nilai text dan url
+const MaxValueLength = 500
+
+// dateLayout adalah format nilai field date
+const dateLayout = "2006-01-02"
+
+// Value adalah nilai custom field yang sudah divalidasi
+// Text berisi bentuk kanonik yang disimpan dan dibandingkan saat filter: angka tanpa trailing zero,
+// tanggal 2006-01-02, true/false untuk checkbox, atau teks apa adanya
+type Value struct {
+	Text   string
+	Number *float64 // hanya diisi untuk field number, dipakai untuk urutan numerik
+}
+
+// Normalize memvalidasi nilai dari body JSON
+// Mengembalikan nil tanpa error jika raw null atau string kosong, artinya nilai field dihapus
+func (f *Field) Normalize(raw interface{}) (*Value, error) {
+	switch value := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, nil
+		}
+		return f.Parse(value)
+	case float64:
+		if f.Type == TypeNumber {
+			return numberValue(value), nil
+		}
+	case bool:
+		if f.Type == TypeCheckbox {
+			return &Value{Text: strconv.FormatBool(value)}, nil
+		}
+	}
+	return nil, f.invalid()
+}
+
+// Parse memvalidasi nilai dalam bentuk string (query string, atau body untuk field text/date/select/url)
+func (f *Field) Parse(raw string) (*Value, error) {
+	switch f.Type {
+	case TypeNumber:
+		number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, f.invalid()
+		}
+		return numberValue(number), nil
+	case TypeDate:
+		date, err := time.Parse(dateLayout, strings.TrimSpace(raw))
+		if err != nil {
+			return nil, f.invalid()
+		}
+		return &Value{Text: date.Format(dateLayout)}, nil
+	case TypeCheckbox:
+		checked, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, f.invalid()
+		}
+		return &Value{Text: strconv.FormatBool(checked)}, nil
+	case TypeSelect:
+		if !slices.Contains(f.Options, raw) {
+			return nil, f.invalid()
+		}
+		return &Value{Text: raw}, nil
+	case TypeURL:
+		raw = strings.TrimSpace(raw)
+		u, err := url.ParseRequestURI(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(raw) > MaxValueLength {
+			return nil, f.invalid()
+		}
+		return &Value{Text: raw}, nil
+	default:
+		if utf8.RuneCountInString(raw) > MaxValueLength {
+			return nil, f.invalid()
+		}
+		return &Value{Text: raw}, nil
+	}
+}
+
+// Decode mengubah nilai kanonik menjadi nilai JSON: float64 untuk number, bool untuk checkbox, selain itu string
+func (f *Field) Decode(text string) interface{} {
+	switch f.Type {
+	case TypeNumber:
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	case TypeCheckbox:
+		if checked, err := strconv.ParseBool(text); err == nil {
+			return checked
+		}
+	}
+	return text
+}
+
+// AppliesTo bernilai true jika field bisa diisi untuk task di projectID (nil = task tanpa project)
+func (f *Field) AppliesTo(projectID *uint) bool {
+	return f.ProjectID == nil || (projectID != nil && *f.ProjectID == *projectID)
+}
+
+// invalid mengembalikan ErrInvalidValue dengan key field untuk pesan terjemahan
+func (f *Field) invalid() error {
+	return ErrInvalidValue.WithParams(map[string]string{"field": f.Key, "type": f.Type})
+}
+
+func numberValue(number float64) *Value {
+	return &Value{Text: strconv.FormatFloat(number, 'f', -1, 64), Number: &number}
+}
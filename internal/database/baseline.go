@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSchemaDrift dikembalikan jika database lama (dibuat AutoMigrate) tidak cocok dengan migration awal
+var ErrSchemaDrift = errors.New("schema database lama tidak cocok dengan migration awal")
+
+// legacyTable adalah tabel yang dibuat AutoMigrate sebelum migration berversi dipakai,
+// beserta migration yang membuat tabel yang sama
+type legacyTable struct {
+	Version int64
+	Table   string
+	Columns []string
+}
+
+// legacySchema adalah schema AutoMigrate lama (auth.User dan task.Task)
+var legacySchema = []legacyTable{
+	{Version: 1, Table: "users", Columns: []string{"id", "username", "email", "password", "created_at", "updated_at"}},
+	{Version: 2, Table: "tasks", Columns: []string{"id", "user_id", "title", "description", "is_completed", "created_at", "updated_at"}},
+}
+
+// legacyColumn adalah kolom migration awal yang belum ada di schema AutoMigrate lama
+type legacyColumn struct {
+	Table      string
+	Column     string
+	Definition string
+}
+
+// legacyAdditions ditambahkan saat adopsi jika belum ada, agar tabel lama sama dengan hasil migration 0001/0002
+var legacyAdditions = []legacyColumn{
+	{Table: "users", Column: "locale", Definition: "VARCHAR(10)"},
+}
+
+// Baseline mengadopsi database yang dibuat AutoMigrate (sebelum migration berversi) agar bisa dimigrasi
+// Kolom yang kurang ditambahkan lalu migration awal dicatat tanpa dijalankan; migration berikutnya tetap pending
+// Tidak melakukan apa-apa untuk database baru atau database yang sudah memakai migration
+// Returns: true jika database diadopsi
+func (m *Migrator) Baseline(ctx context.Context) (bool, error) {
+	adopted := false
+	err := m.withLock(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+		adopted, err = m.adopt(ctx, applied)
+		return err
+	})
+	return adopted, err
+}
+
+// adopt menjalankan Baseline, dipanggil di dalam lock migration
+// Database dianggap lama jika belum ada migration tercatat tetapi tabel users sudah ada
+func (m *Migrator) adopt(ctx context.Context, applied map[int64]schemaMigration) (bool, error) {
+	db := m.db.WithContext(ctx)
+	if len(applied) > 0 || !db.Migrator().HasTable(legacySchema[0].Table) {
+		return false, nil
+	}
+
+	// Semua kolom lama wajib ada, jika tidak schema sudah diubah manual dan harus diperiksa operator
+	var missing []string
+	for _, table := range legacySchema {
+		if !db.Migrator().HasTable(table.Table) {
+			missing = append(missing, table.Table)
+			continue
+		}
+		for _, column := range table.Columns {
+			if !db.Migrator().HasColumn(table.Table, column) {
+				missing = append(missing, table.Table+"."+column)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return false, fmt.Errorf("%w: tidak ditemukan %s", ErrSchemaDrift, strings.Join(missing, ", "))
+	}
+
+	migrations := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrations[migration.Version] = migration
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, addition := range legacyAdditions {
+			if tx.Migrator().HasColumn(addition.Table, addition.Column) {
+				continue
+			}
+			statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", addition.Table, addition.Column, addition.Definition)
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("baseline: %w", err)
+			}
+			slog.InfoContext(ctx, "legacy column added", slog.String("table", addition.Table), slog.String("column", addition.Column))
+		}
+
+		for _, table := range legacySchema {
+			migration, ok := migrations[table.Version]
+			if !ok {
+				return fmt.Errorf("baseline: migration versi %d tidak ditemukan", table.Version)
+			}
+			if err := tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now().UTC(),
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	slog.InfoContext(ctx, "legacy schema adopted", slog.Int("migrations", len(legacySchema)))
+	return true, nil
+}
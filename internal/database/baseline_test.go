@@ -0,0 +1,154 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"rest-api/internal/database"
+	"rest-api/internal/database/dbtest"
+
+	"gorm.io/gorm"
+)
+
+// legacyUser dan legacyTask adalah model yang dulu dibuat AutoMigrate saat server start
+type legacyUser struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	Username  string `gorm:"unique;not null"`
+	Email     string `gorm:"unique;not null"`
+	Password  string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (legacyUser) TableName() string { return "users" }
+
+type legacyTask struct {
+	ID          uint `gorm:"primaryKey"`
+	UserID      uint
+	Title       string `gorm:"not null"`
+	Description string
+	IsCompleted bool `gorm:"default:false"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	User        legacyUser `gorm:"foreignKey:UserID"`
+}
+
+func (legacyTask) TableName() string { return "tasks" }
+
+// driftedUser tidak punya kolom email, seperti schema lama yang diubah manual
+type driftedUser struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement"`
+	Username string `gorm:"not null"`
+}
+
+func (driftedUser) TableName() string { return "users" }
+
+// openLegacy membuat database dengan schema AutoMigrate lama, semua tabel dihapus saat test selesai
+func openLegacy(t *testing.T, models ...interface{}) (*gorm.DB, *database.Migrator) {
+	t.Helper()
+	db := dbtest.OpenEmpty(t)
+	migrator, err := database.NewDefaultMigrator(db)
+	if err != nil {
+		t.Fatalf("NewDefaultMigrator() error = %v", err)
+	}
+	t.Cleanup(func() {
+		migrator.Down(context.Background(), math.MaxInt32)
+		db.Migrator().DropTable("tasks", "users")
+	})
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	return db, migrator
+}
+
+func TestMigrateAdoptsLegacySchema(t *testing.T) {
+	ctx := context.Background()
+	db, migrator := openLegacy(t, &legacyUser{}, &legacyTask{})
+
+	user := &legacyUser{Username: "budi", Email: "budi@mail.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create legacy user: %v", err)
+	}
+	if err := db.Create(&legacyTask{UserID: user.ID, Title: "lama"}).Error; err != nil {
+		t.Fatalf("create legacy task: %v", err)
+	}
+
+	total, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	count, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if count != total-2 {
+		t.Fatalf("Up() = %d, want %d (0001 and 0002 adopted, not run)", count, total-2)
+	}
+	if pending, _ := migrator.Pending(ctx); pending != 0 {
+		t.Fatalf("Pending() after Up() = %d, want 0", pending)
+	}
+
+	// Kolom yang ditambahkan migration awal harus ada agar model sekarang bisa disimpan
+	if !db.Migrator().HasColumn("users", "locale") {
+		t.Fatalf("users.locale missing after adoption")
+	}
+	if err := db.Exec("UPDATE users SET locale = ? WHERE id = ?", "id", user.ID).Error; err != nil {
+		t.Fatalf("update locale: %v", err)
+	}
+	var titles []string
+	if err := db.Table("tasks").Where("user_id = ?", user.ID).Pluck("title", &titles).Error; err != nil || len(titles) != 1 {
+		t.Fatalf("legacy tasks = %v, %v, want existing task kept", titles, err)
+	}
+
+	if adopted, err := migrator.Baseline(ctx); err != nil || adopted {
+		t.Fatalf("Baseline() on migrated database = %v, %v, want false", adopted, err)
+	}
+}
+
+func TestBaselineAdoptsWithoutApplyingLaterMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, migrator := openLegacy(t, &legacyUser{}, &legacyTask{})
+
+	adopted, err := migrator.Baseline(ctx)
+	if err != nil || !adopted {
+		t.Fatalf("Baseline() = %v, %v, want true", adopted, err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if want := status.Version <= 2; status.Applied != want {
+			t.Errorf("Status() %04d applied = %v, want %v", status.Version, status.Applied, want)
+		}
+	}
+	if !db.Migrator().HasColumn("users", "locale") {
+		t.Fatalf("users.locale missing after Baseline()")
+	}
+}
+
+func TestMigrateRejectsDriftedLegacySchema(t *testing.T) {
+	ctx := context.Background()
+	db, migrator := openLegacy(t, &driftedUser{})
+
+	if _, err := migrator.Up(ctx); !errors.Is(err, database.ErrSchemaDrift) {
+		t.Fatalf("Up() error = %v, want %v", err, database.ErrSchemaDrift)
+	}
+	if pending, _ := migrator.Pending(ctx); pending == 0 {
+		t.Fatalf("Up() recorded migrations for a drifted schema")
+	}
+	if db.Migrator().HasColumn("users", "locale") {
+		t.Fatalf("Up() changed a drifted schema")
+	}
+}
+
+func TestBaselineIgnoresNewDatabase(t *testing.T) {
+	_, migrator := openLegacy(t)
+
+	if adopted, err := migrator.Baseline(context.Background()); err != nil || adopted {
+		t.Fatalf("Baseline() on empty database = %v, %v, want false", adopted, err)
+	}
+}
@@ -1,66 +1,178 @@
 // Package database handles database connection and migration
-// Menggunakan GORM sebagai ORM dan MySQL sebagai database
+// Menggunakan GORM sebagai ORM, mendukung MySQL, PostgreSQL dan SQLite
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
+	"rest-api/internal/database/migrations"
 	"rest-api/pkg/config"
+	"rest-api/pkg/tracing"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// Nama driver yang didukung (nilai DB_DRIVER)
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 // DB adalah global variable untuk database connection
 var DB *gorm.DB
 
-// Connect membuat koneksi ke database MySQL
+// Connect membuat koneksi ke database sesuai DB_DRIVER (mysql/postgres/sqlite)
 // Function ini dipanggil saat aplikasi startup
 // Parameters:
 //   - cfg: Config object yang berisi database credentials
 // Returns: error jika koneksi gagal, nil jika berhasil
 func Connect(cfg *config.Config) error {
-	// Build MySQL DSN (Data Source Name) connection string
-	// Format MySQL DSN:
-	//   username:password@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=Local
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName,
-	)
-
-	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
+	db, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+	DB = db
+
+	slog.Info("database connected", slog.String("driver", Dialect(db)))
+
+	return nil
+}
+
+// Open membuka koneksi baru sesuai cfg tanpa mengubah DB global
+// Dipakai oleh Connect dan oleh test yang membutuhkan database terpisah (lihat package dbtest)
+func Open(cfg *config.Config) (*gorm.DB, error) {
+	driver := strings.ToLower(cfg.DBDriver)
+
+	dialector, err := newDialector(driver, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 		NowFunc: func() time.Time {
 			return time.Now().UTC() // Gunakan UTC agar konsisten dengan server
 		},
+		// Error constraint diterjemahkan ke gorm.ErrDuplicatedKey/ErrForeignKeyViolated di semua dialect
+		TranslateError: true,
 	})
 
 	if err != nil {
-		return fmt.Errorf("❌ gagal konek ke database MySQL: %w", err)
+		return nil, fmt.Errorf("❌ gagal konek ke database %s: %w", driver, err)
 	}
 
-	log.Println("✅ Koneksi ke database MySQL berhasil.")
+	// Setiap query menjadi child span dari request (query harus memakai db.WithContext(ctx))
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		return nil, err
+	}
 
-	// Verifikasi database aktif
-	var dbName string
-	DB.Raw("SELECT DATABASE()").Scan(&dbName)
-	log.Println("🔍 Terkoneksi ke database:", dbName)
+	if driver == DriverSQLite {
+		// SQLite hanya mengizinkan satu writer, dan database in-memory hanya hidup
+		// selama koneksinya terbuka, jadi gunakan satu koneksi saja
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
 
-	return nil
+	return db, nil
 }
 
-// Migrate menjalankan database migration untuk semua models
-// AutoMigrate akan membuat tabel jika belum ada
-func Migrate() error {
-	// Migration akan dilakukan di main.go untuk menghindari import cycle
-	log.Println("✅ Migrasi database berhasil.")
+// newDialector membuat GORM dialector untuk driver yang dipilih
+func newDialector(driver string, cfg *config.Config) (gorm.Dialector, error) {
+	switch driver {
+	case DriverMySQL:
+		// Format MySQL DSN:
+		//   username:password@tcp(host:port)/dbname?charset=utf8mb4&parseTime=True&loc=UTC
+		dsn := fmt.Sprintf(
+			"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+			cfg.DBUser, cfg.DBPassword, cfg.DBHost, port(cfg.DBPort, "3306"), cfg.DBName,
+		)
+		return mysql.Open(dsn), nil
+
+	case DriverPostgres:
+		// Format PostgreSQL DSN (key=value), sslmode diambil dari DB_SSLMODE
+		dsn := fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+			cfg.DBHost, port(cfg.DBPort, "5432"), cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode,
+		)
+		return postgres.Open(dsn), nil
+
+	case DriverSQLite:
+		// DB_PATH berisi path file database, atau ":memory:" untuk database in-memory
+		dsn := cfg.DBPath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		if cfg.DBPath != ":memory:" {
+			dsn += "&_pragma=journal_mode(WAL)"
+		}
+		return sqlite.Open(dsn), nil
+
+	default:
+		return nil, fmt.Errorf("❌ DB_DRIVER %q tidak didukung (mysql/postgres/sqlite)", driver)
+	}
+}
+
+// port mengembalikan port dari config, atau port default driver jika kosong
+func port(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// Dialect mengembalikan nama dialect database yang aktif (mysql/postgres/sqlite)
+// Dipakai untuk query yang syntax-nya berbeda antar database
+func Dialect(db *gorm.DB) string {
+	return db.Dialector.Name()
+}
+
+// NewDefaultMigrator membuat Migrator dengan file migration bawaan (internal/database/migrations)
+func NewDefaultMigrator(db *gorm.DB) (*Migrator, error) {
+	list, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(db, list), nil
+}
+
+// Migrate menjalankan semua migration yang belum dijalankan
+// Aman dijalankan beberapa instance sekaligus karena dilindungi advisory lock
+func Migrate(ctx context.Context) error {
+	migrator, err := NewDefaultMigrator(DB)
+	if err != nil {
+		return err
+	}
+
+	count, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "database migrated", slog.Int("applied", count))
 	return nil
 }
 
+// Close menutup connection pool database
+// Dipanggil saat graceful shutdown setelah semua request dan worker selesai
+func Close(ctx context.Context) error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 // GetDB mengembalikan instance *gorm.DB
 func GetDB() *gorm.DB {
 	return DB
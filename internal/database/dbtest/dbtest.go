@@ -0,0 +1,89 @@
+// Package dbtest menyediakan database yang sudah dimigrasi untuk test repository
+//
+// Default-nya SQLite in-memory (database baru per test). Untuk menjalankan test yang sama di MySQL atau
+// PostgreSQL, set TEST_DB_DRIVER beserta koneksinya, contoh:
+//
+//	TEST_DB_DRIVER=postgres TEST_DB_HOST=localhost TEST_DB_USER=postgres TEST_DB_PASSWORD=secret \
+//	TEST_DB_NAME=task_api_test go test -p 1 ./...
+//
+// Database MySQL/PostgreSQL dikosongkan (migration down lalu up) di awal setiap test, jadi gunakan
+// database khusus test dan -p 1 agar package tidak berjalan bersamaan di database yang sama
+package dbtest
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+
+	"rest-api/internal/database"
+	"rest-api/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// Open membuka database test sesuai TEST_DB_DRIVER (default sqlite) dengan semua migration terpasang
+// Koneksi ditutup otomatis saat test selesai
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+	db := OpenEmpty(t)
+
+	migrator, err := database.NewDefaultMigrator(db)
+	if err != nil {
+		t.Fatalf("dbtest: load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("dbtest: migrate: %v", err)
+	}
+	return db
+}
+
+// OpenEmpty membuka database test tanpa tabel migration bawaan, untuk test migrator
+// Tabel lain yang dibuat test harus dihapus sendiri oleh test tersebut
+func OpenEmpty(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	cfg := &config.Config{
+		DBDriver:   getEnv("TEST_DB_DRIVER", database.DriverSQLite),
+		DBHost:     getEnv("TEST_DB_HOST", "localhost"),
+		DBPort:     os.Getenv("TEST_DB_PORT"),
+		DBUser:     os.Getenv("TEST_DB_USER"),
+		DBPassword: os.Getenv("TEST_DB_PASSWORD"),
+		DBName:     os.Getenv("TEST_DB_NAME"),
+		DBSSLMode:  getEnv("TEST_DB_SSLMODE", "disable"),
+		DBPath:     ":memory:",
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("dbtest: open %s: %v", cfg.DBDriver, err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := database.NewDefaultMigrator(db)
+	if err != nil {
+		t.Fatalf("dbtest: load migrations: %v", err)
+	}
+	if cfg.DBDriver != database.DriverSQLite {
+		if _, err := migrator.Down(context.Background(), math.MaxInt32); err != nil {
+			t.Fatalf("dbtest: reset database: %v", err)
+		}
+	}
+	return db
+}
+
+// Dialect mengembalikan nama driver yang dipakai Open, untuk test yang hanya relevan di dialect tertentu
+func Dialect() string {
+	return getEnv("TEST_DB_DRIVER", database.DriverSQLite)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
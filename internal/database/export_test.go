@@ -0,0 +1,8 @@
+package database
+
+import "context"
+
+// WithLock membuka withLock untuk test di package database_test
+func (m *Migrator) WithLock(ctx context.Context, fn func() error) error {
+	return m.withLock(ctx, fn)
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// MigrationsDir adalah folder source file migration, dipakai oleh "migrate create"
+const MigrationsDir = "internal/database/migrations"
+
+// RunMigrateCommand menjalankan subcommand migrate dari command line
+// Usage:
+//
+//	migrate up           menjalankan semua migration yang belum dijalankan
+//	migrate down [N]     membatalkan N migration terakhir (default 1)
+//	migrate status       menampilkan status semua migration
+//	migrate baseline     mengadopsi database lama yang dibuat AutoMigrate
+//	migrate create NAME  membuat file migration baru di internal/database/migrations
+func RunMigrateCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate <up|down [N]|status|baseline|create NAME>")
+	}
+
+	// create tidak butuh koneksi database
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migrate create NAME")
+		}
+		up, down, err := CreateMigration(MigrationsDir, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created %s\nCreated %s\n", up, down)
+		return nil
+	}
+
+	migrator, err := NewDefaultMigrator(DB)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration applied\n", count)
+
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+				return fmt.Errorf("jumlah migration %q tidak valid", args[1])
+			}
+		}
+		count, err := migrator.Down(ctx, n)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration rolled back\n", count)
+
+	case "baseline":
+		adopted, err := migrator.Baseline(ctx)
+		if err != nil {
+			return err
+		}
+		if adopted {
+			fmt.Println("existing schema adopted, run migrate up to apply the remaining migrations")
+		} else {
+			fmt.Println("nothing to adopt")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", "-"
+			if s.Applied {
+				state, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Dirty {
+				state = "applied (checksum mismatch)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	return nil
+}
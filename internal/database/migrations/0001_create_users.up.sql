@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS users (
+    id {{ .ID }},
+    username {{ .String }} NOT NULL,
+    email {{ .String }} NOT NULL,
+    password {{ .Text }} NOT NULL,
+    locale VARCHAR(10),
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT uni_users_username UNIQUE (username),
+    CONSTRAINT uni_users_email UNIQUE (email)
+){{ .TableOptions }};
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id {{ .ID }},
+    user_id {{ .FK }},
+    title {{ .Text }} NOT NULL,
+    description {{ .Text }},
+    is_completed {{ .Bool }} DEFAULT false,
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_tasks_user FOREIGN KEY (user_id) REFERENCES users (id)
+){{ .TableOptions }};
@@ -0,0 +1,17 @@
+-- Audit log bersifat append-only: tidak ada foreign key ke users agar event tetap ada walaupun user dihapus
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id {{ .ID }},
+    actor_id {{ .FK }} NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(50) NOT NULL DEFAULT '',
+    target_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes {{ .Text }},
+    metadata {{ .Text }},
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent {{ .String }} NOT NULL DEFAULT '',
+    created_at {{ .Timestamp }} NOT NULL
+){{ .TableOptions }};
+
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,14 @@
+-- Riwayat perubahan task per field, revision dinomori per task mulai dari 1
+-- Revision 0 adalah state task saat dibuat (tidak disimpan sebagai baris)
+CREATE TABLE IF NOT EXISTS task_revisions (
+    id {{ .ID }},
+    task_id {{ .FK }} NOT NULL,
+    user_id {{ .FK }} NULL,
+    revision INT NOT NULL,
+    changes {{ .Text }} NOT NULL,
+    reverted_to INT NULL,
+    created_at {{ .Timestamp }} NOT NULL,
+    CONSTRAINT fk_task_revisions_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE UNIQUE INDEX idx_task_revisions_task_revision ON task_revisions (task_id, revision);
@@ -0,0 +1,2 @@
+DROP INDEX idx_tasks_deleted_at{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- Soft delete: task yang dihapus masuk trash sampai di-restore atau di-purge
+ALTER TABLE tasks ADD COLUMN deleted_at {{ .Timestamp }} NULL;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN auto_archive_days;
+DROP INDEX idx_tasks_archived_at{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN archived_at;
+ALTER TABLE tasks DROP COLUMN completed_at;
@@ -0,0 +1,10 @@
+-- Arsip terpisah dari status selesai dan soft delete
+-- completed_at dipakai untuk auto-archive, task yang sudah selesai diisi dari updated_at
+ALTER TABLE tasks ADD COLUMN completed_at {{ .Timestamp }} NULL;
+ALTER TABLE tasks ADD COLUMN archived_at {{ .Timestamp }} NULL;
+UPDATE tasks SET completed_at = updated_at WHERE is_completed = true;
+
+CREATE INDEX idx_tasks_archived_at ON tasks (archived_at);
+
+-- Preferensi auto-archive per user dalam hari (0 = nonaktif)
+ALTER TABLE users ADD COLUMN auto_archive_days INT NOT NULL DEFAULT 0;
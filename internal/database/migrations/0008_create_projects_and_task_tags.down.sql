@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_tags;
+DROP INDEX idx_tasks_project_id{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN priority;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id {{ .ID }},
+    user_id {{ .FK }} NOT NULL,
+    name {{ .String }} NOT NULL,
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_projects_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE UNIQUE INDEX idx_projects_user_name ON projects (user_id, name);
+
+-- project_id tanpa foreign key, task dikeluarkan dari project oleh service saat project dihapus
+ALTER TABLE tasks ADD COLUMN project_id {{ .FK }} NULL;
+ALTER TABLE tasks ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'none';
+
+CREATE INDEX idx_tasks_project_id ON tasks (project_id);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id {{ .FK }} NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (task_id, tag),
+    CONSTRAINT fk_task_tags_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_task_tags_tag ON task_tags (tag);
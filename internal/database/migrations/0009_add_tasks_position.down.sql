@@ -0,0 +1,2 @@
+DROP INDEX idx_tasks_user_position{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN position;
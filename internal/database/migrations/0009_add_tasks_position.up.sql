@@ -0,0 +1,4 @@
+-- Manual ordering: key urutan task (pkg/rank), task lama diberi posisi saat pertama kali diurutkan
+ALTER TABLE tasks ADD COLUMN position VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_user_position ON tasks (user_id, position);
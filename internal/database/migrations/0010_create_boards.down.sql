@@ -0,0 +1,4 @@
+DROP INDEX idx_tasks_column_id{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN column_id;
+DROP TABLE IF EXISTS board_columns;
+DROP TABLE IF EXISTS boards;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS boards (
+    id {{ .ID }},
+    user_id {{ .FK }} NOT NULL,
+    name {{ .String }} NOT NULL,
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_boards_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_boards_user_id ON boards (user_id);
+
+-- status none = kolom bebas, open/completed = task diubah statusnya saat dipindahkan ke kolom
+CREATE TABLE IF NOT EXISTS board_columns (
+    id {{ .ID }},
+    board_id {{ .FK }} NOT NULL,
+    name {{ .String }} NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'none',
+    wip_limit INT NOT NULL DEFAULT 0,
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_board_columns_board FOREIGN KEY (board_id) REFERENCES boards (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_board_columns_board_id ON board_columns (board_id);
+
+-- column_id tanpa foreign key seperti project_id, task dikeluarkan dari board oleh service saat kolom dihapus
+ALTER TABLE tasks ADD COLUMN column_id {{ .FK }} NULL;
+
+CREATE INDEX idx_tasks_column_id ON tasks (column_id);
@@ -0,0 +1,11 @@
+-- task_id diblokir oleh blocked_by_id: task_id baru bisa diselesaikan setelah blocked_by_id selesai
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id {{ .FK }} NOT NULL,
+    blocked_by_id {{ .FK }} NOT NULL,
+    created_at {{ .Timestamp }},
+    PRIMARY KEY (task_id, blocked_by_id),
+    CONSTRAINT fk_task_dependencies_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_dependencies_blocked_by FOREIGN KEY (blocked_by_id) REFERENCES tasks (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies (blocked_by_id);
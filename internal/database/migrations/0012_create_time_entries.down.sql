@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN estimate_minutes;
+DROP TABLE IF EXISTS time_entries;
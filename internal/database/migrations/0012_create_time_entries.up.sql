@@ -0,0 +1,21 @@
+-- Waktu kerja per task, ended_at kosong berarti timer masih berjalan (maksimal satu per user)
+-- duration dalam detik, diisi saat timer dihentikan atau dari entry manual
+CREATE TABLE IF NOT EXISTS time_entries (
+    id {{ .ID }},
+    user_id {{ .FK }} NOT NULL,
+    task_id {{ .FK }} NOT NULL,
+    started_at {{ .Timestamp }} NOT NULL,
+    ended_at {{ .Timestamp }} NULL,
+    duration INT NOT NULL DEFAULT 0,
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_time_entries_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_time_entries_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_time_entries_user_started_at ON time_entries (user_id, started_at);
+CREATE INDEX idx_time_entries_task_id ON time_entries (task_id);
+
+-- Estimasi waktu pengerjaan task dalam menit
+ALTER TABLE tasks ADD COLUMN estimate_minutes INT NULL;
@@ -0,0 +1,32 @@
+-- Definisi custom field milik user, project_id diisi jika field hanya berlaku untuk task di project tersebut
+-- field_key dipakai di request dan query task (key adalah reserved word di MySQL)
+-- options berisi JSON array pilihan untuk type select
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id {{ .ID }},
+    user_id {{ .FK }} NOT NULL,
+    project_id {{ .FK }} NULL,
+    field_key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options {{ .Text }},
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_custom_fields_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_custom_fields_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE UNIQUE INDEX idx_custom_fields_user_key ON custom_fields (user_id, field_key);
+CREATE INDEX idx_custom_fields_project_id ON custom_fields (project_id);
+
+-- Nilai custom field per task dalam bentuk kanonik, number_value diisi untuk field number agar bisa diurutkan
+CREATE TABLE IF NOT EXISTS task_field_values (
+    task_id {{ .FK }} NOT NULL,
+    field_id {{ .FK }} NOT NULL,
+    value VARCHAR(500) NOT NULL,
+    number_value DOUBLE PRECISION NULL,
+    PRIMARY KEY (task_id, field_id),
+    CONSTRAINT fk_task_field_values_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_field_values_field FOREIGN KEY (field_id) REFERENCES custom_fields (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_task_field_values_field_id ON task_field_values (field_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS saved_view_shares;
+DROP TABLE IF EXISTS saved_views;
+DROP INDEX idx_tasks_user_due_at{{ if eq .Dialect "mysql" }} ON tasks{{ end }};
+ALTER TABLE tasks DROP COLUMN due_at;
@@ -0,0 +1,29 @@
+-- Tenggat task, dipakai oleh filter due dan smart list (Today, Next 7 days, Overdue, No due date)
+ALTER TABLE tasks ADD COLUMN due_at {{ .Timestamp }} NULL;
+
+CREATE INDEX idx_tasks_user_due_at ON tasks (user_id, due_at);
+
+-- View tersimpan milik user, filter berisi JSON task.ListQuery
+CREATE TABLE IF NOT EXISTS saved_views (
+    id {{ .ID }},
+    user_id {{ .FK }} NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter {{ .Text }} NOT NULL,
+    created_at {{ .Timestamp }},
+    updated_at {{ .Timestamp }},
+    CONSTRAINT fk_saved_views_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_saved_views_user_id ON saved_views (user_id);
+
+-- User yang bisa melihat task hasil view milik user lain (read-only)
+CREATE TABLE IF NOT EXISTS saved_view_shares (
+    view_id {{ .FK }} NOT NULL,
+    user_id {{ .FK }} NOT NULL,
+    created_at {{ .Timestamp }},
+    PRIMARY KEY (view_id, user_id),
+    CONSTRAINT fk_saved_view_shares_view FOREIGN KEY (view_id) REFERENCES saved_views (id) ON DELETE CASCADE,
+    CONSTRAINT fk_saved_view_shares_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+CREATE INDEX idx_saved_view_shares_user_id ON saved_view_shares (user_id);
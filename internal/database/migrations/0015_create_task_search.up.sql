@@ -0,0 +1,26 @@
+-- Index full-text task (judul dan deskripsi), diisi ulang oleh task service setiap kali judul/deskripsi berubah
+-- SQLite memakai tabel virtual FTS5 dengan rowid = ID task, MySQL memakai FULLTEXT index dan
+-- PostgreSQL memakai kolom tsvector (config 'simple' karena task bisa berbahasa Indonesia maupun Inggris)
+{{ if eq .Dialect "sqlite" -}}
+CREATE VIRTUAL TABLE IF NOT EXISTS task_search USING fts5(title, content, tokenize = 'unicode61 remove_diacritics 2');
+
+INSERT INTO task_search (rowid, title, content) SELECT id, title, COALESCE(description, '') FROM tasks;
+{{- else -}}
+CREATE TABLE IF NOT EXISTS task_search (
+    task_id {{ .FK }} NOT NULL PRIMARY KEY,
+    title {{ .Text }} NOT NULL,
+    content {{ .Text }} NOT NULL,
+{{- if eq .Dialect "postgres" }}
+    document TSVECTOR GENERATED ALWAYS AS (setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', content), 'B')) STORED,
+{{- end }}
+    CONSTRAINT fk_task_search_task FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+){{ .TableOptions }};
+
+{{ if eq .Dialect "mysql" -}}
+CREATE FULLTEXT INDEX idx_task_search_fulltext ON task_search (title, content);
+{{- else -}}
+CREATE INDEX idx_task_search_document ON task_search USING GIN (document);
+{{- end }}
+
+INSERT INTO task_search (task_id, title, content) SELECT id, title, COALESCE(description, '') FROM tasks;
+{{- end }}
@@ -0,0 +1,4 @@
+DROP INDEX idx_time_entries_running{{ if eq .Dialect "mysql" }} ON time_entries{{ end }};
+{{ if eq .Dialect "mysql" -}}
+ALTER TABLE time_entries DROP COLUMN running_user_id;
+{{- end }}
@@ -0,0 +1,16 @@
+-- Maksimal satu timer berjalan (ended_at kosong) per user, dijaga database agar dua StartTimer bersamaan
+-- tidak bisa sama-sama berhasil. Timer berjalan ganda dari data lama dihentikan dulu (durasi 0), kecuali yang terbaru
+UPDATE time_entries SET ended_at = started_at
+WHERE ended_at IS NULL AND id NOT IN (
+    SELECT id FROM (SELECT MAX(id) AS id FROM time_entries WHERE ended_at IS NULL GROUP BY user_id) AS latest
+);
+
+-- MySQL tidak punya partial index, jadi index dibuat di generated column yang hanya berisi user_id
+-- selama timer berjalan (NULL tidak dianggap duplikat oleh unique index)
+{{ if eq .Dialect "mysql" -}}
+ALTER TABLE time_entries ADD COLUMN running_user_id {{ .FK }} AS (CASE WHEN ended_at IS NULL THEN user_id END) VIRTUAL;
+
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries (running_user_id);
+{{- else -}}
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries (user_id) WHERE ended_at IS NULL;
+{{- end }}
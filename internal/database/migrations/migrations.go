@@ -0,0 +1,22 @@
+// Package migrations berisi file SQL migration yang di-embed ke binary
+// Nama file: <version>_<nama>.up.sql dan <version>_<nama>.down.sql (contoh: 0001_create_users.up.sql)
+// File SQL adalah text/template yang dirender per dialect database, placeholder yang tersedia:
+//   - {{ .ID }}           primary key auto increment
+//   - {{ .FK }}           kolom integer untuk foreign key
+//   - {{ .String }}       VARCHAR yang bisa di-index (unique, index)
+//   - {{ .Text }}         teks panjang
+//   - {{ .Bool }}         boolean
+//   - {{ .Timestamp }}    tanggal dan waktu
+//   - {{ .TableOptions }} opsi tabel (ENGINE/CHARSET untuk MySQL)
+//   - {{ .Dialect }}      nama dialect (mysql/postgres/sqlite) untuk SQL khusus per database
+//
+// Statement dipisahkan oleh ";" di akhir baris. Statement yang mengandung ";" di dalamnya
+// (contoh: trigger) dibungkus dengan baris "-- +migrate StatementBegin" dan "-- +migrate StatementEnd"
+package migrations
+
+import "embed"
+
+// FS berisi semua file migration
+//
+//go:embed *.sql
+var FS embed.FS
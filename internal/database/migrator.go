@@ -0,0 +1,488 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchemaTable adalah nama tabel yang mencatat migration yang sudah dijalankan
+const SchemaTable = "schema_migrations"
+
+// lockName adalah nama advisory lock agar hanya satu instance yang menjalankan migration
+const lockName = "task_api_schema_migrations"
+
+// migrationFile cocok dengan nama file migration, contoh: 0001_create_users.up.sql
+var migrationFile = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// ErrChecksumMismatch dikembalikan jika file migration yang sudah dijalankan diubah
+var ErrChecksumMismatch = errors.New("checksum migration tidak cocok")
+
+// Migration adalah satu versi perubahan schema
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string // Template SQL untuk menerapkan migration
+	Down     string // Template SQL untuk membatalkan migration
+	Checksum string // SHA-256 dari file up, untuk mendeteksi file yang diubah setelah dijalankan
+}
+
+// MigrationStatus adalah status satu migration di database
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+	Dirty     bool       `json:"dirty"` // true jika checksum file berbeda dengan yang tercatat
+}
+
+// schemaMigration adalah baris di tabel schema_migrations
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	Checksum  string    `gorm:"size:64;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string { return SchemaTable }
+
+// Migrator menjalankan migration berversi dan mencatatnya di tabel schema_migrations
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []Migration
+	LockTimeout time.Duration // Batas waktu menunggu advisory lock dari instance lain
+}
+
+// NewMigrator membuat Migrator untuk daftar migration yang sudah di-load
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations, LockTimeout: 5 * time.Minute}
+}
+
+// LoadMigrations membaca semua file migration dari fsys, terurut berdasarkan versi
+// Setiap versi wajib memiliki file up dan down
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration versi %d memiliki dua nama: %s dan %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s harus memiliki file up dan down", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up menjalankan semua migration yang belum dijalankan
+// Database lama yang dibuat AutoMigrate diadopsi dulu (lihat Baseline)
+// Returns: jumlah migration yang dijalankan
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+		if adopted, err := m.adopt(ctx, applied); err != nil {
+			return err
+		} else if adopted {
+			if applied, err = m.verify(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, migration, true); err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "migration applied", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down membatalkan n migration terakhir yang sudah dijalankan (urutan terbalik)
+// Returns: jumlah migration yang dibatalkan
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < n; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(ctx, migration, false); err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "migration rolled back", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status mengembalikan status semua migration
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = &appliedAt
+			statuses[i].Dirty = row.Checksum != migration.Checksum
+		}
+	}
+	return statuses, nil
+}
+
+// Pending mengembalikan jumlah migration yang belum dijalankan
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// apply menjalankan file up/down satu migration dan memperbarui schema_migrations dalam satu transaksi
+// Catatan: MySQL melakukan implicit commit untuk DDL, sehingga rollback hanya efektif di PostgreSQL/SQLite
+func (m *Migrator) apply(ctx context.Context, migration Migration, up bool) error {
+	source := migration.Down
+	if up {
+		source = migration.Up
+	}
+
+	statements, err := m.render(source)
+	if err != nil {
+		return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		if !up {
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now().UTC(),
+		}).Error
+	})
+}
+
+// verify memastikan file migration yang sudah dijalankan tidak diubah
+func (m *Migrator) verify(ctx context.Context) (map[int64]schemaMigration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[int64]bool{}
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		if row, ok := applied[migration.Version]; ok && row.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	for version, row := range applied {
+		if !known[version] {
+			slog.WarnContext(ctx, "applied migration file not found", slog.Int64("version", version), slog.String("name", row.Name))
+		}
+	}
+
+	return applied, nil
+}
+
+// applied membaca migration yang sudah dijalankan, membuat tabel schema_migrations jika belum ada
+func (m *Migrator) applied(ctx context.Context) (map[int64]schemaMigration, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// render merender template SQL untuk dialect aktif lalu memecahnya menjadi statement
+func (m *Migrator) render(source string) ([]string, error) {
+	tmpl, err := template.New("migration").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, dialectTypes(Dialect(m.db))); err != nil {
+		return nil, err
+	}
+	return splitStatements(buf.String()), nil
+}
+
+// dialectTypes mengembalikan nilai placeholder template untuk setiap dialect
+func dialectTypes(dialect string) map[string]string {
+	types := map[string]string{
+		"Dialect":      dialect,
+		"String":       "VARCHAR(191)",
+		"Text":         "TEXT",
+		"Bool":         "BOOLEAN",
+		"TableOptions": "",
+	}
+
+	switch dialect {
+	case DriverMySQL:
+		types["ID"] = "BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY"
+		types["FK"] = "BIGINT UNSIGNED"
+		types["Text"] = "LONGTEXT"
+		types["Timestamp"] = "DATETIME(3)"
+		types["TableOptions"] = " ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	case DriverPostgres:
+		types["ID"] = "BIGSERIAL PRIMARY KEY"
+		types["FK"] = "BIGINT"
+		types["Timestamp"] = "TIMESTAMPTZ"
+	default:
+		types["ID"] = "INTEGER PRIMARY KEY AUTOINCREMENT"
+		types["FK"] = "INTEGER"
+		types["Timestamp"] = "DATETIME"
+	}
+
+	return types
+}
+
+// splitStatements memecah SQL menjadi statement berdasarkan ";" di akhir baris
+// Blok "-- +migrate StatementBegin" ... "-- +migrate StatementEnd" dianggap satu statement utuh
+func splitStatements(source string) []string {
+	var statements []string
+	var current strings.Builder
+	inBlock := false
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+		}
+		current.Reset()
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "-- +migrate StatementBegin":
+			flush()
+			inBlock = true
+			continue
+		case trimmed == "-- +migrate StatementEnd":
+			flush()
+			inBlock = false
+			continue
+		case strings.HasPrefix(trimmed, "--") || trimmed == "":
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// localLock membatasi migration SQLite ke satu goroutine per proses
+var localLock sync.Mutex
+
+// withLock menjalankan fn sambil memegang advisory lock database
+// Sehingga saat rolling deploy hanya satu instance yang menjalankan migration
+// MySQL memakai GET_LOCK, PostgreSQL memakai pg_advisory_lock
+// SQLite hanya dipakai satu proses, jadi cukup mutex agar dua Migrate tidak menjalankan migration yang sama
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	dialect := Dialect(m.db)
+	if dialect != DriverMySQL && dialect != DriverPostgres {
+		localLock.Lock()
+		defer localLock.Unlock()
+		return fn()
+	}
+
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+
+	// Advisory lock terikat ke session, jadi lock dan unlock harus lewat koneksi yang sama
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := acquireLock(ctx, conn, dialect, m.LockTimeout); err != nil {
+		return err
+	}
+	defer func() {
+		if err := releaseLock(context.Background(), conn, dialect); err != nil {
+			slog.WarnContext(ctx, "failed to release migration lock", slog.Any("error", err))
+		}
+	}()
+
+	return fn()
+}
+
+func acquireLock(ctx context.Context, conn *sql.Conn, dialect string, timeout time.Duration) error {
+	switch dialect {
+	case DriverMySQL:
+		var acquired sql.NullInt64
+		err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(timeout.Seconds())).Scan(&acquired)
+		if err != nil {
+			return err
+		}
+		if acquired.Int64 != 1 {
+			return fmt.Errorf("timeout menunggu lock migration setelah %s", timeout)
+		}
+		return nil
+	default:
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey())
+		return err
+	}
+}
+
+func releaseLock(ctx context.Context, conn *sql.Conn, dialect string) error {
+	var err error
+	switch dialect {
+	case DriverMySQL:
+		_, err = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+	default:
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey())
+	}
+	return err
+}
+
+// lockKey mengubah lockName menjadi key integer untuk pg_advisory_lock
+func lockKey() int64 {
+	return int64(crc32.ChecksumIEEE([]byte(lockName)))
+}
+
+// CreateMigration membuat pasangan file migration baru di dir dengan versi berikutnya
+// Returns: path file up dan down yang dibuat
+func CreateMigration(dir, name string) (string, string, error) {
+	name = strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.TrimSpace(name)))
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(name) {
+		return "", "", fmt.Errorf("nama migration %q tidak valid, gunakan huruf kecil, angka dan underscore", name)
+	}
+
+	existing, err := LoadMigrations(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	var version int64 = 1
+	if len(existing) > 0 {
+		version = existing[len(existing)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+
+	if err := os.WriteFile(up, []byte("-- Tulis SQL untuk menerapkan migration di sini\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- Tulis SQL untuk membatalkan migration di sini\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
@@ -0,0 +1,220 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"rest-api/internal/database"
+	"rest-api/internal/database/dbtest"
+
+	"gorm.io/gorm"
+)
+
+// Migration test memakai versi 9001 ke atas dan tabel migrator_* agar tidak bentrok dengan migration bawaan
+var testMigrations = fstest.MapFS{
+	"9001_create_widgets.up.sql":      {Data: []byte("CREATE TABLE migrator_widgets (\n    id {{ .ID }},\n    name {{ .String }} NOT NULL\n){{ .TableOptions }};\n")},
+	"9001_create_widgets.down.sql":    {Data: []byte("DROP TABLE migrator_widgets;\n")},
+	"9002_add_widgets_color.up.sql":   {Data: []byte("ALTER TABLE migrator_widgets ADD COLUMN color VARCHAR(20);\n")},
+	"9002_add_widgets_color.down.sql": {Data: []byte("ALTER TABLE migrator_widgets DROP COLUMN color;\n")},
+	"9003_create_gadgets.up.sql":      {Data: []byte("CREATE TABLE migrator_gadgets (\n    id {{ .ID }}\n){{ .TableOptions }};\n")},
+	"9003_create_gadgets.down.sql":    {Data: []byte("DROP TABLE migrator_gadgets;\n")},
+	"README.md":                       {Data: []byte("bukan migration")},
+}
+
+// newTestMigrator membuat Migrator untuk testMigrations, semua migration dibatalkan saat test selesai
+func newTestMigrator(t *testing.T, db *gorm.DB, fsys fstest.MapFS) *database.Migrator {
+	t.Helper()
+	list, err := database.LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	migrator := database.NewMigrator(db, list)
+	t.Cleanup(func() { migrator.Down(context.Background(), math.MaxInt32) })
+	return migrator
+}
+
+func applied(t *testing.T, migrator *database.Migrator) map[int64]bool {
+	t.Helper()
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	result := map[int64]bool{}
+	for _, status := range statuses {
+		result[status.Version] = status.Applied
+	}
+	return result
+}
+
+func TestLoadMigrations(t *testing.T) {
+	list, err := database.LoadMigrations(testMigrations)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	if len(list) != 3 || list[0].Version != 9001 || list[1].Name != "add_widgets_color" || list[2].Version != 9003 {
+		t.Fatalf("LoadMigrations() = %+v, want 9001-9003 in order", list)
+	}
+
+	missingDown := fstest.MapFS{"0001_create_x.up.sql": {Data: []byte("SELECT 1;")}}
+	if _, err := database.LoadMigrations(missingDown); err == nil {
+		t.Fatalf("LoadMigrations() without down file error = nil")
+	}
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.OpenEmpty(t)
+	migrator := newTestMigrator(t, db, testMigrations)
+
+	if pending, err := migrator.Pending(ctx); err != nil || pending != 3 {
+		t.Fatalf("Pending() = %d, %v, want 3", pending, err)
+	}
+	if count, err := migrator.Up(ctx); err != nil || count != 3 {
+		t.Fatalf("Up() = %d, %v, want 3", count, err)
+	}
+	if !db.Migrator().HasColumn("migrator_widgets", "color") || !db.Migrator().HasTable("migrator_gadgets") {
+		t.Fatalf("Up() did not create the schema")
+	}
+	if count, err := migrator.Up(ctx); err != nil || count != 0 {
+		t.Fatalf("second Up() = %d, %v, want 0", count, err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.AppliedAt == nil || status.Dirty {
+			t.Errorf("Status() %04d = %+v, want applied and clean", status.Version, status)
+		}
+	}
+
+	// Down membatalkan dari versi terakhir
+	if count, err := migrator.Down(ctx, 2); err != nil || count != 2 {
+		t.Fatalf("Down(2) = %d, %v, want 2", count, err)
+	}
+	if got := applied(t, migrator); !got[9001] || got[9002] || got[9003] {
+		t.Fatalf("applied after Down(2) = %v, want only 9001", got)
+	}
+	if db.Migrator().HasColumn("migrator_widgets", "color") || db.Migrator().HasTable("migrator_gadgets") {
+		t.Fatalf("Down(2) did not roll back the schema")
+	}
+
+	if count, err := migrator.Down(ctx, 5); err != nil || count != 1 {
+		t.Fatalf("Down(5) = %d, %v, want 1", count, err)
+	}
+	if db.Migrator().HasTable("migrator_widgets") {
+		t.Fatalf("Down(5) did not drop migrator_widgets")
+	}
+}
+
+func TestMigratorRejectsChangedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.OpenEmpty(t)
+	if _, err := newTestMigrator(t, db, testMigrations).Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	changed := fstest.MapFS{}
+	for name, file := range testMigrations {
+		changed[name] = file
+	}
+	changed["9002_add_widgets_color.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE migrator_widgets ADD COLUMN colour VARCHAR(20);\n")}
+	list, err := database.LoadMigrations(changed)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	migrator := database.NewMigrator(db, list)
+
+	if _, err := migrator.Up(ctx); !errors.Is(err, database.ErrChecksumMismatch) {
+		t.Fatalf("Up() error = %v, want %v", err, database.ErrChecksumMismatch)
+	}
+	if _, err := migrator.Down(ctx, 1); !errors.Is(err, database.ErrChecksumMismatch) {
+		t.Fatalf("Down() error = %v, want %v", err, database.ErrChecksumMismatch)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if want := status.Version == 9002; status.Dirty != want {
+			t.Errorf("Status() %04d dirty = %v, want %v", status.Version, status.Dirty, want)
+		}
+	}
+}
+
+func TestMigratorUpWaitsForLock(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.OpenEmpty(t)
+	holder := newTestMigrator(t, db, testMigrations)
+	migrator := newTestMigrator(t, db, testMigrations)
+
+	held, release := make(chan struct{}), make(chan struct{})
+	go holder.WithLock(ctx, func() error {
+		close(held)
+		<-release
+		return nil
+	})
+	<-held
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := migrator.Up(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Up() returned %v while another migration held the lock", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Up() still waiting after the lock was released")
+	}
+	if got := applied(t, migrator); !got[9001] || !got[9002] || !got[9003] {
+		t.Fatalf("applied = %v, want all", got)
+	}
+}
+
+func TestMigratorConcurrentUpAppliesOnce(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.OpenEmpty(t)
+
+	const instances = 4
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	counts := make([]int, instances)
+	errs := make([]error, instances)
+	for i := range instances {
+		migrator := newTestMigrator(t, db, testMigrations)
+		wg.Go(func() {
+			<-start
+			counts[i], errs[i] = migrator.Up(ctx)
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	total := 0
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Up() instance %d error = %v", i, err)
+		}
+		total += counts[i]
+	}
+	if total != 3 {
+		t.Errorf("Up() applied %d migrations across instances, want 3", total)
+	}
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"errors"
+
+	"rest-api/internal/database"
+	"rest-api/pkg/lifecycle"
+
+	"gorm.io/gorm"
+)
+
+// Checker memeriksa satu dependency untuk readiness
+// Modul lain (contoh: storage, mailer) cukup mengimplementasikan interface ini
+// lalu mendaftarkannya lewat Service.Register
+type Checker interface {
+	// Name adalah nama komponen di response (contoh: database)
+	Name() string
+	// Check mengembalikan detail opsional dan error jika komponen tidak sehat
+	// ctx sudah dibatasi oleh HEALTH_CHECK_TIMEOUT
+	Check(ctx context.Context) (interface{}, error)
+}
+
+type checkerFunc struct {
+	name string
+	fn   func(ctx context.Context) (interface{}, error)
+}
+
+func (c checkerFunc) Name() string { return c.name }
+
+func (c checkerFunc) Check(ctx context.Context) (interface{}, error) { return c.fn(ctx) }
+
+// NewChecker membuat Checker dari sebuah function
+func NewChecker(name string, fn func(ctx context.Context) (interface{}, error)) Checker {
+	return checkerFunc{name: name, fn: fn}
+}
+
+// DatabaseChecker melakukan ping ke database dan melaporkan statistik connection pool
+func DatabaseChecker(db *gorm.DB) Checker {
+	return NewChecker("database", func(ctx context.Context) (interface{}, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return nil, err
+		}
+
+		stats := sqlDB.Stats()
+		return map[string]interface{}{
+			"dialect":         database.Dialect(db),
+			"openConnections": stats.OpenConnections,
+			"inUse":           stats.InUse,
+			"idle":            stats.Idle,
+		}, nil
+	})
+}
+
+// MigrationChecker gagal jika masih ada migration yang belum dijalankan
+// atau checksum migration yang sudah dijalankan tidak cocok
+func MigrationChecker(db *gorm.DB) Checker {
+	// File migration di-embed ke binary, cukup dimuat sekali
+	migrator, loadErr := database.NewDefaultMigrator(db)
+
+	return NewChecker("migrations", func(ctx context.Context) (interface{}, error) {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details := map[string]int{"pending": pending}
+		if pending > 0 {
+			return details, errors.New("pending migrations")
+		}
+		return details, nil
+	})
+}
+
+// LifecycleChecker gagal saat aplikasi belum siap atau sedang shutdown
+func LifecycleChecker(lc *lifecycle.Manager) Checker {
+	return NewChecker("lifecycle", func(ctx context.Context) (interface{}, error) {
+		if lc.Stopping() {
+			return nil, errors.New("shutting down")
+		}
+		if !lc.Ready() {
+			return nil, errors.New("starting")
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,40 @@
+package health
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Liveness probe
+// @Description Selalu 200 selama proses berjalan, tidak memeriksa dependency
+// @Tags Health
+// @Produce json
+// @Success 200 {object} Report
+// @Router /healthz [get]
+func (ctrl *Controller) Liveness(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(ctrl.service.Liveness())
+}
+
+// @Summary Readiness probe
+// @Description Memeriksa database, status migration dan checker lain. 503 jika ada komponen yang down atau aplikasi sedang shutdown
+// @Tags Health
+// @Produce json
+// @Success 200 {object} Report
+// @Failure 503 {object} Report
+// @Router /readyz [get]
+func (ctrl *Controller) Readiness(c *fiber.Ctx) error {
+	report := ctrl.service.Readiness(c.UserContext())
+
+	status := fiber.StatusOK
+	if report.Status != StatusUp {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(report)
+}
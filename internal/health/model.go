@@ -0,0 +1,25 @@
+package health
+
+import "time"
+
+// Status komponen dan keseluruhan aplikasi
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Report adalah response /healthz dan /readyz
+type Report struct {
+	Status     string                     `json:"status" example:"up"`
+	Timestamp  time.Time                  `json:"timestamp"`
+	Uptime     string                     `json:"uptime" example:"1h2m3s"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentStatus adalah hasil pemeriksaan satu dependency
+type ComponentStatus struct {
+	Status    string      `json:"status" example:"up"`
+	LatencyMs float64     `json:"latencyMs" example:"1.25"`
+	Error     string      `json:"error,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,11 @@
+package health
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupRoutes mendaftarkan endpoint probe di root (bukan /api) agar mudah dipakai orchestrator
+func SetupRoutes(app *fiber.App, ctrl *Controller) {
+	app.Get("/healthz", ctrl.Liveness)
+	app.Get("/readyz", ctrl.Readiness)
+}
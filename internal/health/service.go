@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout adalah batas waktu setiap checker jika HEALTH_CHECK_TIMEOUT tidak valid
+const DefaultTimeout = 2 * time.Second
+
+// Service defines the interface for health business logic
+type Service interface {
+	Register(checker Checker)
+	Liveness() *Report
+	Readiness(ctx context.Context) *Report
+}
+
+// service implements Service.
+type service struct {
+	startedAt time.Time
+	timeout   time.Duration
+
+	mu       sync.RWMutex
+	checkers []Checker
+}
+
+// NewService membuat health service, timeout membatasi setiap checker
+func NewService(timeout time.Duration, checkers ...Checker) Service {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &service{startedAt: time.Now(), timeout: timeout, checkers: checkers}
+}
+
+// Register menambahkan checker untuk readiness
+func (s *service) Register(checker Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkers = append(s.checkers, checker)
+}
+
+// Liveness hanya menandakan proses masih berjalan, tanpa memeriksa dependency
+func (s *service) Liveness() *Report {
+	return s.report(StatusUp, nil)
+}
+
+// Readiness menjalankan semua checker secara paralel
+// Status keseluruhan down jika ada satu komponen yang down
+func (s *service) Readiness(ctx context.Context) *Report {
+	s.mu.RLock()
+	checkers := append([]Checker(nil), s.checkers...)
+	s.mu.RUnlock()
+
+	components := make(map[string]ComponentStatus, len(checkers))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, checker := range checkers {
+		wg.Add(1)
+		go func(checker Checker) {
+			defer wg.Done()
+			result := s.run(ctx, checker)
+
+			mu.Lock()
+			components[checker.Name()] = result
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	status := StatusUp
+	for _, component := range components {
+		if component.Status != StatusUp {
+			status = StatusDown
+			break
+		}
+	}
+	return s.report(status, components)
+}
+
+// run menjalankan satu checker dengan timeout
+// Checker yang tidak menghormati ctx tetap dianggap down saat timeout terlewati
+func (s *service) run(ctx context.Context, checker Checker) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	type outcome struct {
+		details interface{}
+		err     error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		details, err := checker.Check(ctx)
+		done <- outcome{details, err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = ctx.Err()
+	}
+
+	status := ComponentStatus{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   result.details,
+	}
+	if result.err != nil {
+		status.Status = StatusDown
+		status.Error = result.err.Error()
+	}
+	return status
+}
+
+func (s *service) report(status string, components map[string]ComponentStatus) *Report {
+	return &Report{
+		Status:     status,
+		Timestamp:  time.Now().UTC(),
+		Uptime:     time.Since(s.startedAt).Round(time.Second).String(),
+		Components: components,
+	}
+}
@@ -0,0 +1,155 @@
+package project
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Create project
+// @Description Buat project baru untuk mengelompokkan task, nama project unik per user
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param data body CreateRequest true "Project data"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/projects [post]
+func (ctrl *Controller) CreateProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req CreateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	project, err := ctrl.service.CreateProject(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "PROJECT_CREATED", fiber.Map{
+		"project": project,
+	})
+}
+
+// @Summary List projects
+// @Description Semua project milik user, diurutkan berdasarkan nama
+// @Tags Projects
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/projects [get]
+func (ctrl *Controller) GetProjects(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	projects, err := ctrl.service.GetProjects(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "PROJECTS_RETRIEVED", fiber.Map{
+		"projects": projects,
+	})
+}
+
+// @Summary Get project detail
+// @Description Get detail of a project by ID
+// @Tags Projects
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/projects/{id} [get]
+func (ctrl *Controller) GetProjectByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidProjectID
+	}
+
+	project, err := ctrl.service.GetProjectByID(c.UserContext(), user.ID, uint(projectID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "PROJECT_RETRIEVED", fiber.Map{
+		"project": project,
+	})
+}
+
+// @Summary Rename project
+// @Description Ubah nama project
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param data body UpdateRequest true "Project data"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/projects/{id} [put]
+func (ctrl *Controller) UpdateProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidProjectID
+	}
+
+	var req UpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	project, err := ctrl.service.UpdateProject(c.UserContext(), user.ID, uint(projectID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "PROJECT_UPDATED", fiber.Map{
+		"project": project,
+	})
+}
+
+// @Summary Delete project
+// @Description Hapus project, task di dalamnya tidak ikut dihapus melainkan dikeluarkan dari project
+// @Tags Projects
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/projects/{id} [delete]
+func (ctrl *Controller) DeleteProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidProjectID
+	}
+
+	if err := ctrl.service.DeleteProject(c.UserContext(), user.ID, uint(projectID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "PROJECT_DELETED", fiber.Map{})
+}
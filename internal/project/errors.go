@@ -0,0 +1,15 @@
+package project
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul project
+var (
+	ErrProjectNotFound     = apperror.NotFound("PROJECT_NOT_FOUND", "project not found")
+	ErrProjectForbidden    = apperror.Forbidden("PROJECT_FORBIDDEN", "unauthorized to access this project")
+	ErrInvalidProjectID    = apperror.BadRequest("INVALID_PROJECT_ID", "Invalid project ID")
+	ErrProjectNameInUse    = apperror.Conflict("PROJECT_NAME_IN_USE", "project name already in use")
+	ErrProjectCreateFailed = apperror.Internal("PROJECT_CREATE_FAILED", "failed to create project", nil)
+	ErrProjectFetchFailed  = apperror.Internal("PROJECT_FETCH_FAILED", "failed to retrieve project", nil)
+	ErrProjectUpdateFailed = apperror.Internal("PROJECT_UPDATE_FAILED", "failed to update project", nil)
+	ErrProjectDeleteFailed = apperror.Internal("PROJECT_DELETE_FAILED", "failed to delete project", nil)
+)
@@ -0,0 +1,30 @@
+package project
+
+import "time"
+
+// Project mengelompokkan task milik satu user, nama project unik per user
+type Project struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null" json:"userId"`
+	Name      string    `gorm:"size:191;not null" json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Request DTOs
+type CreateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// Response DTOs
+type Response struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UserID    uint      `json:"userId"`
+}
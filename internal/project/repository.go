@@ -0,0 +1,80 @@
+package project
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, project *Project) error
+	Update(ctx context.Context, project *Project) error
+	FindByID(ctx context.Context, id uint) (*Project, error)
+	FindAllByUserID(ctx context.Context, userID uint) ([]Project, error)
+	ExistsByName(ctx context.Context, userID uint, name string) (bool, error)
+	Delete(ctx context.Context, project *Project) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+func (r *repository) Create(ctx context.Context, project *Project) error {
+	return r.db.WithContext(ctx).Create(project).Error
+}
+
+// Update implements Repository.
+func (r *repository) Update(ctx context.Context, project *Project) error {
+	return r.db.WithContext(ctx).Save(project).Error
+}
+
+// FindByID implements Repository.
+func (r *repository) FindByID(ctx context.Context, id uint) (*Project, error) {
+	var project Project
+	if err := r.db.WithContext(ctx).First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// FindAllByUserID implements Repository.
+func (r *repository) FindAllByUserID(ctx context.Context, userID uint) ([]Project, error) {
+	var projects []Project
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name asc").
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ExistsByName implements Repository.
+func (r *repository) ExistsByName(ctx context.Context, userID uint, name string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&Project{}).
+		Where("user_id = ? AND name = ?", userID, name).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete implements Repository.
+// Task di dalam project (termasuk yang ada di trash) dikeluarkan dari project, bukan ikut dihapus
+func (r *repository) Delete(ctx context.Context, project *Project) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("tasks").
+			Where("project_id = ?", project.ID).
+			Update("project_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(project).Error
+	})
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
@@ -0,0 +1,18 @@
+package project
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	projects := app.Group("/api/projects")
+
+	projects.Post("/", middlewares.Auth(cfg), ctrl.CreateProject)
+	projects.Get("/", middlewares.Auth(cfg), ctrl.GetProjects)
+	projects.Get("/:id", middlewares.Auth(cfg), ctrl.GetProjectByID)
+	projects.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateProject)
+	projects.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteProject)
+}
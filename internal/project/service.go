@@ -0,0 +1,165 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"rest-api/pkg/auditlog"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	CreateProject(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetProjects(ctx context.Context, userID uint) ([]Response, error)
+	GetProjectByID(ctx context.Context, userID, id uint) (*Response, error)
+	UpdateProject(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error)
+	DeleteProject(ctx context.Context, userID, id uint) error
+}
+
+type service struct {
+	repo  Repository
+	audit auditlog.Recorder
+}
+
+// CreateProject implements Service.
+func (s *service) CreateProject(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.ensureNameAvailable(ctx, userID, name); err != nil {
+		return nil, err
+	}
+
+	project := &Project{UserID: userID, Name: name}
+	if err := s.repo.Create(ctx, project); err != nil {
+		// Request lain bisa membuat nama yang sama setelah ensureNameAvailable, unique index yang memutuskan
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrProjectNameInUse
+		}
+		return nil, ErrProjectCreateFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionProjectCreate, auditlog.TargetProject, project.ID)
+	entry.Changes = auditlog.Diff(nil, map[string]interface{}{"name": project.Name})
+	s.audit.Record(ctx, entry)
+
+	return toResponse(project), nil
+}
+
+// GetProjects implements Service.
+func (s *service) GetProjects(ctx context.Context, userID uint) ([]Response, error) {
+	projects, err := s.repo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrProjectFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(projects))
+	for i := range projects {
+		responses[i] = *toResponse(&projects[i])
+	}
+
+	return responses, nil
+}
+
+// GetProjectByID implements Service.
+func (s *service) GetProjectByID(ctx context.Context, userID, id uint) (*Response, error) {
+	project, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(project), nil
+}
+
+// UpdateProject implements Service.
+func (s *service) UpdateProject(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	project, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == project.Name {
+		return toResponse(project), nil
+	}
+	if err := s.ensureNameAvailable(ctx, userID, name); err != nil {
+		return nil, err
+	}
+
+	before := project.Name
+	project.Name = name
+	if err := s.repo.Update(ctx, project); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrProjectNameInUse
+		}
+		return nil, ErrProjectUpdateFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionProjectUpdate, auditlog.TargetProject, project.ID)
+	entry.Changes = auditlog.Changes{"name": {From: before, To: project.Name}}
+	s.audit.Record(ctx, entry)
+
+	return toResponse(project), nil
+}
+
+// DeleteProject implements Service.
+// Task di dalam project tidak ikut dihapus, hanya dikeluarkan dari project
+func (s *service) DeleteProject(ctx context.Context, userID, id uint) error {
+	project, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, project); err != nil {
+		return ErrProjectDeleteFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionProjectDelete, auditlog.TargetProject, project.ID)
+	entry.Changes = auditlog.Diff(map[string]interface{}{"name": project.Name}, nil)
+	s.audit.Record(ctx, entry)
+
+	return nil
+}
+
+// findOwned mengambil project dan memastikan project milik userID
+func (s *service) findOwned(ctx context.Context, userID, id uint) (*Project, error) {
+	project, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, ErrProjectFetchFailed.Wrap(err)
+	}
+
+	if project.UserID != userID {
+		return nil, ErrProjectForbidden
+	}
+
+	return project, nil
+}
+
+// ensureNameAvailable memastikan user belum punya project dengan nama yang sama
+func (s *service) ensureNameAvailable(ctx context.Context, userID uint, name string) error {
+	exists, err := s.repo.ExistsByName(ctx, userID, name)
+	if err != nil {
+		return ErrProjectFetchFailed.Wrap(err)
+	}
+	if exists {
+		return ErrProjectNameInUse
+	}
+	return nil
+}
+
+// toResponse mengubah model Project menjadi response DTO
+func toResponse(project *Project) *Response {
+	return &Response{
+		ID:        project.ID,
+		Name:      project.Name,
+		CreatedAt: project.CreatedAt,
+		UpdatedAt: project.UpdatedAt,
+		UserID:    project.UserID,
+	}
+}
+
+func NewService(repo Repository, audit auditlog.Recorder) Service {
+	return withTracing(&service{repo: repo, audit: audit})
+}
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/database/dbtest"
+	"rest-api/pkg/auditlog"
+)
+
+// racingRepository melewati pengecekan nama, seperti request lain yang menyimpan nama sama
+// tepat setelah ensureNameAvailable selesai
+type racingRepository struct {
+	Repository
+}
+
+// ExistsByName implements Repository.
+func (racingRepository) ExistsByName(context.Context, uint, string) (bool, error) {
+	return false, nil
+}
+
+func TestProjectNameInUseOnUniqueIndex(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	user := &auth.User{Username: "budi", Email: "budi@mail.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	svc := NewService(racingRepository{NewRepository(db)}, auditlog.Nop())
+
+	if _, err := svc.CreateProject(ctx, user.ID, &CreateRequest{Name: "Kantor"}); err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if _, err := svc.CreateProject(ctx, user.ID, &CreateRequest{Name: "Kantor"}); !errors.Is(err, ErrProjectNameInUse) {
+		t.Fatalf("CreateProject() duplicate error = %v, want %v", err, ErrProjectNameInUse)
+	}
+
+	other, err := svc.CreateProject(ctx, user.ID, &CreateRequest{Name: "Rumah"})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if _, err := svc.UpdateProject(ctx, user.ID, other.ID, &UpdateRequest{Name: "Kantor"}); !errors.Is(err, ErrProjectNameInUse) {
+		t.Fatalf("UpdateProject() duplicate error = %v, want %v", err, ErrProjectNameInUse)
+	}
+}
@@ -0,0 +1,57 @@
+package project
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// CreateProject implements Service.
+func (t *tracedService) CreateProject(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "project.CreateProject", attribute.Int("user.id", int(userID)))
+	res, err := t.next.CreateProject(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetProjects implements Service.
+func (t *tracedService) GetProjects(ctx context.Context, userID uint) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "project.GetProjects", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetProjects(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetProjectByID implements Service.
+func (t *tracedService) GetProjectByID(ctx context.Context, userID, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "project.GetProjectByID", attribute.Int("user.id", int(userID)), attribute.Int("project.id", int(id)))
+	res, err := t.next.GetProjectByID(ctx, userID, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateProject implements Service.
+func (t *tracedService) UpdateProject(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "project.UpdateProject", attribute.Int("user.id", int(userID)), attribute.Int("project.id", int(id)))
+	res, err := t.next.UpdateProject(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteProject implements Service.
+func (t *tracedService) DeleteProject(ctx context.Context, userID, id uint) error {
+	ctx, span := tracing.Start(ctx, "project.DeleteProject", attribute.Int("user.id", int(userID)), attribute.Int("project.id", int(id)))
+	err := t.next.DeleteProject(ctx, userID, id)
+	tracing.End(span, err)
+	return err
+}
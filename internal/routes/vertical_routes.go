@@ -1,34 +1,129 @@
 package routes
 
 import (
+	"context"
+	"strconv"
+	"time"
+
+	"rest-api/internal/audit"
 	"rest-api/internal/auth"
+	"rest-api/internal/board"
+	"rest-api/internal/customfield"
 	"rest-api/internal/database"
+	"rest-api/internal/health"
+	"rest-api/internal/project"
+	"rest-api/internal/search"
 	"rest-api/internal/task"
+	"rest-api/internal/timeentry"
 	"rest-api/internal/user"
+	"rest-api/internal/view"
 	"rest-api/pkg/config"
+	"rest-api/pkg/lifecycle"
+	"rest-api/pkg/security"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // SetupVerticalRoutes sets up routes using the vertical layer architecture
-func SetupVerticalRoutes(app *fiber.App, cfg *config.Config) {
+// Background worker modul didaftarkan ke lc agar ikut dihentikan saat shutdown
+// Error dikembalikan jika konfigurasi modul tidak valid (contoh: parameter hashing password)
+func SetupVerticalRoutes(app *fiber.App, cfg *config.Config, lc *lifecycle.Manager) error {
 	db := database.GetDB()
+	hasher, err := security.NewPasswordHasher(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize Health module (vertical)
+	// Checker tambahan (contoh: storage, mailer) didaftarkan lewat healthService.Register
+	checkTimeout, _ := time.ParseDuration(cfg.HealthCheckTimeout)
+	healthService := health.NewService(checkTimeout,
+		health.LifecycleChecker(lc),
+		health.DatabaseChecker(db),
+		health.MigrationChecker(db),
+	)
+	healthController := health.NewController(healthService)
+	health.SetupRoutes(app, healthController)
+
+	// Initialize Audit module (vertical)
+	// Audit service dipakai sebagai auditlog.Recorder oleh modul lain
+	retentionDays, _ := strconv.Atoi(cfg.AuditRetentionDays)
+	auditRepo := audit.NewRepository(db)
+	auditService := audit.NewService(auditRepo, time.Duration(retentionDays)*24*time.Hour)
+	auditController := audit.NewController(auditService)
+	audit.SetupRoutes(app, cfg, auditController)
+	lc.Every("audit-retention", time.Hour, func(ctx context.Context) error {
+		_, err := auditService.PurgeExpired(ctx)
+		return err
+	})
 
 	// Initialize Auth module (vertical)
 	authRepo := auth.NewRepository(db)
-	authService := auth.NewService(authRepo, cfg)
+	authService := auth.NewService(authRepo, cfg, hasher, auditService)
 	authController := auth.NewController(authService, cfg)
 	auth.SetupRoutes(app, authController)
 
 	// Initialize User module (vertical)
 	userRepo := user.NewRepository(db)
-	userService := user.NewService(userRepo)
+	userService := user.NewService(userRepo, hasher, auditService)
 	userController := user.NewController(userService)
 	user.SetupRoutes(app, cfg, userController)
 
+	// Initialize Project module (vertical)
+	projectRepo := project.NewRepository(db)
+	projectService := project.NewService(projectRepo, auditService)
+	projectController := project.NewController(projectService)
+	project.SetupRoutes(app, cfg, projectController)
+
+	// Initialize Custom Field module (vertical)
+	customFieldRepo := customfield.NewRepository(db)
+	customFieldService := customfield.NewService(customFieldRepo, auditService)
+	customFieldController := customfield.NewController(customFieldService)
+	customfield.SetupRoutes(app, cfg, customFieldController)
+
 	// Initialize Task module (vertical)
+	// Search index diperbarui oleh taskService setiap kali judul/deskripsi task berubah
+	searchIndex := search.NewIndex(db)
 	taskRepo := task.NewRepository(db)
-	taskService := task.NewService(taskRepo)
+	taskService := task.NewService(taskRepo, cfg, auditService, searchIndex)
 	taskController := task.NewController(taskService)
 	task.SetupRoutes(app, cfg, taskController)
+	// Task di trash dihapus permanen setelah TRASH_RETENTION_DAYS
+	trashDays, _ := strconv.Atoi(cfg.TrashRetentionDays)
+	lc.Every("task-trash-purge", time.Hour, func(ctx context.Context) error {
+		_, err := taskService.PurgeTrash(ctx, time.Duration(trashDays)*24*time.Hour)
+		return err
+	})
+	// Task selesai diarsipkan otomatis sesuai preferensi auto_archive_days setiap user
+	lc.Every("task-auto-archive", time.Hour, func(ctx context.Context) error {
+		_, err := taskService.AutoArchive(ctx)
+		return err
+	})
+
+	// Initialize Board module (vertical)
+	// Card board adalah task, dipindahkan lewat taskService agar revision dan audit log tetap tercatat
+	boardRepo := board.NewRepository(db)
+	boardService := board.NewService(boardRepo, taskService, auditService)
+	boardController := board.NewController(boardService)
+	board.SetupRoutes(app, cfg, boardController)
+
+	// Initialize Time Entry module (vertical)
+	timeEntryRepo := timeentry.NewRepository(db)
+	timeEntryService := timeentry.NewService(timeEntryRepo, taskService)
+	timeEntryController := timeentry.NewController(timeEntryService)
+	timeentry.SetupRoutes(app, cfg, timeEntryController)
+
+	// Initialize View module (vertical)
+	// View dievaluasi lewat taskService sehingga filter sama persis dengan GET /api/tasks
+	viewRepo := view.NewRepository(db)
+	viewService := view.NewService(viewRepo, taskService)
+	viewController := view.NewController(viewService)
+	view.SetupRoutes(app, cfg, viewController)
+
+	// Initialize Search module (vertical)
+	searchService := search.NewService(searchIndex)
+	searchController := search.NewController(searchService)
+	search.SetupRoutes(app, cfg, searchController)
+
+	return nil
 }
@@ -0,0 +1,50 @@
+package search
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Search tasks
+// @Description Cari task user berdasarkan judul dan deskripsi, diurutkan berdasarkan relevansi. Semua kata di q harus cocok (sebagai awalan kata). Title dan snippet sudah di-escape HTML, kata yang cocok dibungkus <mark>
+// @Tags Search
+// @Produce json
+// @Param q query string true "Kata kunci"
+// @Param projectId query int false "Filter project"
+// @Param completed query bool false "Filter status selesai"
+// @Param priority query string false "Filter prioritas (none/low/medium/high)"
+// @Param tag query string false "Filter tag"
+// @Param archived query string false "exclude (default), include atau only"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} response.SuccessResponse{data=ListResponse}
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/search [get]
+func (ctrl *Controller) Search(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query Query
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	result, err := ctrl.service.Search(c.UserContext(), user.ID, &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "SEARCH_RESULTS_RETRIEVED", result)
+}
@@ -0,0 +1,9 @@
+package search
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul search
+var (
+	ErrInvalidQuery = apperror.BadRequest("INVALID_SEARCH_QUERY", "search query must contain at least one letter or digit")
+	ErrSearchFailed = apperror.Internal("SEARCH_FAILED", "failed to search tasks", nil)
+)
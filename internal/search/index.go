@@ -0,0 +1,144 @@
+// Package search menyediakan pencarian full-text task memakai fitur full-text bawaan database
+// (SQLite FTS5, MySQL FULLTEXT, PostgreSQL tsvector) di tabel task_search
+package search
+
+import (
+	"context"
+	"rest-api/internal/database"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// MaxTerms adalah jumlah maksimal kata dari query yang dipakai untuk mencari
+const MaxTerms = 10
+
+// SearchIndex adalah index full-text task
+// Index dan Remove dipanggil oleh task service setiap kali judul/deskripsi task berubah atau task dihapus permanen
+type SearchIndex interface {
+	// Index menambah atau mengganti dokumen task
+	Index(ctx context.Context, doc Document) error
+	// Remove menghapus dokumen task
+	Remove(ctx context.Context, taskID uint) error
+	// Prune menghapus dokumen yang task-nya sudah tidak ada (contoh: setelah purge trash)
+	Prune(ctx context.Context) (int64, error)
+	// Rebuild mengisi ulang seluruh index dari tabel tasks
+	Rebuild(ctx context.Context) (int64, error)
+	// Search mencari task milik filter.UserID yang cocok dengan semua term, diurutkan berdasarkan relevansi
+	Search(ctx context.Context, filter Filter) ([]Hit, int64, error)
+}
+
+// NewIndex membuat SearchIndex sesuai dialect database yang aktif
+func NewIndex(db *gorm.DB) SearchIndex {
+	switch database.Dialect(db) {
+	case database.DriverMySQL:
+		return &mysqlIndex{table{db: db, key: "task_id"}}
+	case database.DriverPostgres:
+		return &postgresIndex{table{db: db, key: "task_id"}}
+	default:
+		return &sqliteIndex{table{db: db, key: "rowid"}}
+	}
+}
+
+// table berisi operasi task_search yang sama di semua dialect
+// key adalah kolom ID task di task_search (rowid untuk FTS5)
+type table struct {
+	db  *gorm.DB
+	key string
+}
+
+// Remove implements SearchIndex.
+func (t *table) Remove(ctx context.Context, taskID uint) error {
+	return t.db.WithContext(ctx).Exec("DELETE FROM task_search WHERE "+t.key+" = ?", taskID).Error
+}
+
+// Prune implements SearchIndex.
+func (t *table) Prune(ctx context.Context) (int64, error) {
+	result := t.db.WithContext(ctx).Exec("DELETE FROM task_search WHERE " + t.key + " NOT IN (SELECT id FROM tasks)")
+	return result.RowsAffected, result.Error
+}
+
+// Rebuild implements SearchIndex.
+// Task di trash ikut di-index agar langsung bisa dicari setelah di-restore
+func (t *table) Rebuild(ctx context.Context) (int64, error) {
+	var count int64
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_search").Error; err != nil {
+			return err
+		}
+		result := tx.Exec("INSERT INTO task_search (" + t.key + ", title, content) SELECT id, title, COALESCE(description, '') FROM tasks")
+		count = result.RowsAffected
+		return result.Error
+	})
+	return count, err
+}
+
+// search menjalankan pencarian dengan kondisi match dan ekspresi skor milik dialect
+// expr adalah ekspresi query full-text, dipakai sebagai argumen match dan score (jika score memakai placeholder)
+func (t *table) search(ctx context.Context, filter Filter, match, score, expr string) ([]Hit, int64, error) {
+	query := t.db.WithContext(ctx).
+		Table("task_search").
+		Joins("JOIN tasks ON tasks.id = task_search."+t.key).
+		Where(match, expr).
+		Where("tasks.user_id = ? AND tasks.deleted_at IS NULL", filter.UserID)
+
+	if filter.Archived == "only" {
+		query = query.Where("tasks.archived_at IS NOT NULL")
+	} else if filter.Archived != "include" {
+		query = query.Where("tasks.archived_at IS NULL")
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("tasks.project_id = ?", *filter.ProjectID)
+	}
+	if filter.Completed != nil {
+		query = query.Where("tasks.is_completed = ?", *filter.Completed)
+	}
+	if filter.Priority != "" {
+		query = query.Where("tasks.priority = ?", filter.Priority)
+	}
+	if filter.Tag != "" {
+		query = query.Where("tasks.id IN (?)", t.db.WithContext(ctx).Table("task_tags").Select("task_id").Where("tag = ?", filter.Tag))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Ekspresi skor tidak selalu memakai argumen (contoh: bm25 di SQLite)
+	var args []interface{}
+	if strings.Contains(score, "?") {
+		args = append(args, expr)
+	}
+
+	var hits []Hit
+	err := query.
+		Select("tasks.id AS task_id, task_search.title, task_search.content, tasks.project_id, tasks.priority, tasks.is_completed, "+
+			"tasks.archived_at IS NOT NULL AS archived, "+score+" AS score", args...).
+		Order("score DESC, tasks.id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Scan(&hits).Error
+	return hits, total, err
+}
+
+// terms memecah query menjadi kata (huruf kecil, tanpa tanda baca dan duplikat), maksimal MaxTerms kata
+// Operator full-text setiap database ikut terbuang sehingga input user tidak bisa mengubah syntax query
+func terms(q string) []string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(result) == MaxTerms {
+			break
+		}
+		if !slices.Contains(result, word) {
+			result = append(result, word)
+		}
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package search_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/database/dbtest"
+	"rest-api/internal/search"
+	"rest-api/internal/task"
+
+	"gorm.io/gorm"
+)
+
+// Test memakai index sesuai dialect dbtest (SQLite FTS5 secara default), kata minimal 3 huruf agar juga
+// ter-index di MySQL (innodb_ft_min_token_size)
+
+func createUser(t *testing.T, db *gorm.DB, name string) uint {
+	t.Helper()
+	user := &auth.User{Username: name, Email: name + "@mail.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user.ID
+}
+
+// createIndexed menyimpan task lalu meng-index judul dan deskripsinya seperti task service
+func createIndexed(t *testing.T, db *gorm.DB, index search.SearchIndex, item *task.Task) *task.Task {
+	t.Helper()
+	if item.Priority == "" {
+		item.Priority = task.PriorityNone
+	}
+	if err := db.Create(item).Error; err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := index.Index(context.Background(), search.Document{TaskID: item.ID, Title: item.Title, Content: item.Description}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	return item
+}
+
+func hitIDs(hits []search.Hit) []uint {
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.TaskID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestIndexSearch(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	index := search.NewIndex(db)
+	userID := createUser(t, db, "budi")
+	otherID := createUser(t, db, "ani")
+
+	projectID, archivedAt := uint(1), time.Now().UTC()
+	meeting := createIndexed(t, db, index, &task.Task{UserID: userID, Title: "Rapat kantor mingguan", Description: "Bahas laporan keuangan",
+		ProjectID: &projectID, Priority: task.PriorityHigh, Tags: []task.Tag{{Tag: "kantor"}}})
+	report := createIndexed(t, db, index, &task.Task{UserID: userID, Title: "Laporan keuangan bulanan", Description: "Kirim dokumen kantor pusat",
+		IsCompleted: true})
+	archived := createIndexed(t, db, index, &task.Task{UserID: userID, Title: "Belanja mingguan", ArchivedAt: &archivedAt})
+	trashed := createIndexed(t, db, index, &task.Task{UserID: userID, Title: "Rapat kantor lama"})
+	other := createIndexed(t, db, index, &task.Task{UserID: otherID, Title: "Rapat kantor cabang"})
+	if err := db.Delete(trashed).Error; err != nil {
+		t.Fatalf("trash task: %v", err)
+	}
+
+	completed := true
+	tests := []struct {
+		name   string
+		filter search.Filter
+		want   []uint
+	}{
+		{"title and content", search.Filter{Terms: []string{"kantor"}}, []uint{meeting.ID, report.ID}},
+		{"excludes trash and other users", search.Filter{Terms: []string{"rapat"}}, []uint{meeting.ID}},
+		{"all terms must match", search.Filter{Terms: []string{"laporan", "bulanan"}}, []uint{report.ID}},
+		{"terms match word prefixes", search.Filter{Terms: []string{"kan", "keu"}}, []uint{meeting.ID, report.ID}},
+		{"no match", search.Filter{Terms: []string{"liburan"}}, []uint{}},
+		{"archived excluded by default", search.Filter{Terms: []string{"mingguan"}}, []uint{meeting.ID}},
+		{"archived include", search.Filter{Terms: []string{"mingguan"}, Archived: "include"}, []uint{meeting.ID, archived.ID}},
+		{"archived only", search.Filter{Terms: []string{"mingguan"}, Archived: "only"}, []uint{archived.ID}},
+		{"project", search.Filter{Terms: []string{"kantor"}, ProjectID: &projectID}, []uint{meeting.ID}},
+		{"completed", search.Filter{Terms: []string{"kantor"}, Completed: &completed}, []uint{report.ID}},
+		{"priority", search.Filter{Terms: []string{"kantor"}, Priority: task.PriorityHigh}, []uint{meeting.ID}},
+		{"tag", search.Filter{Terms: []string{"kantor"}, Tag: "kantor"}, []uint{meeting.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.UserID, tt.filter.Limit = userID, 20
+			hits, total, err := index.Search(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got := hitIDs(hits); !slices.Equal(got, tt.want) || total != int64(len(tt.want)) {
+				t.Fatalf("Search() = %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+
+	t.Run("pagination keeps the total", func(t *testing.T) {
+		hits, total, err := index.Search(ctx, search.Filter{UserID: userID, Terms: []string{"kantor"}, Offset: 1, Limit: 1})
+		if err != nil || len(hits) != 1 || total != 2 {
+			t.Fatalf("Search() = %d hits (total %d), %v, want 1 of 2", len(hits), total, err)
+		}
+	})
+
+	t.Run("hits carry task fields", func(t *testing.T) {
+		hits, _, err := index.Search(ctx, search.Filter{UserID: userID, Terms: []string{"rapat"}, Limit: 20})
+		if err != nil || len(hits) != 1 {
+			t.Fatalf("Search() = %v, %v, want one hit", hits, err)
+		}
+		hit := hits[0]
+		if hit.Title != meeting.Title || hit.Content != meeting.Description || hit.ProjectID == nil || *hit.ProjectID != projectID ||
+			hit.Priority != task.PriorityHigh || hit.IsCompleted || hit.Archived || hit.Score <= 0 {
+			t.Fatalf("Search() hit = %+v, want fields of task %d", hit, meeting.ID)
+		}
+	})
+
+	// Index mengganti dokumen lama, Remove menghapusnya
+	if err := index.Index(ctx, search.Document{TaskID: report.ID, Title: "Laporan pajak", Content: "Kirim dokumen"}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if err := index.Remove(ctx, meeting.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	for _, term := range []string{"kantor", "keuangan"} {
+		if hits, _, err := index.Search(ctx, search.Filter{UserID: userID, Terms: []string{term}, Limit: 20}); err != nil || len(hits) != 0 {
+			t.Fatalf("Search(%s) after reindex and remove = %v, %v, want none", term, hitIDs(hits), err)
+		}
+	}
+
+	// Prune hanya menghapus dokumen yang task-nya sudah dihapus permanen, task di trash tetap di-index
+	if err := db.Unscoped().Delete(other).Error; err != nil {
+		t.Fatalf("purge task: %v", err)
+	}
+	if pruned, err := index.Prune(ctx); err != nil || pruned != 1 {
+		t.Fatalf("Prune() = %d, %v, want 1", pruned, err)
+	}
+
+	rebuilt, err := index.Rebuild(ctx)
+	if err != nil || rebuilt != 4 {
+		t.Fatalf("Rebuild() = %d, %v, want 4", rebuilt, err)
+	}
+	hits, _, err := index.Search(ctx, search.Filter{UserID: userID, Terms: []string{"kantor"}, Limit: 20})
+	if err != nil || !slices.Equal(hitIDs(hits), []uint{meeting.ID, report.ID}) {
+		t.Fatalf("Search() after Rebuild() = %v, %v, want %v", hitIDs(hits), err, []uint{meeting.ID, report.ID})
+	}
+}
@@ -0,0 +1,65 @@
+package search
+
+// Document adalah teks task yang di-index, content berisi deskripsi task
+type Document struct {
+	TaskID  uint
+	Title   string
+	Content string
+}
+
+// Filter adalah kriteria pencarian untuk SearchIndex, field kosong/nil diabaikan
+type Filter struct {
+	UserID    uint
+	Terms     []string // semua term harus cocok, dicocokkan sebagai awalan kata (contoh: "rap" cocok dengan "rapat")
+	ProjectID *uint
+	Completed *bool
+	Priority  string
+	Tag       string
+	Archived  string // exclude (default), include atau only
+	Offset    int
+	Limit     int
+}
+
+// Hit adalah satu task yang cocok beserta skor relevansinya (semakin besar semakin relevan)
+type Hit struct {
+	TaskID      uint
+	Title       string
+	Content     string
+	ProjectID   *uint
+	Priority    string
+	IsCompleted bool
+	Archived    bool
+	Score       float64
+}
+
+// Query DTOs
+type Query struct {
+	Q         string `json:"q" query:"q" validate:"required,max=200"`
+	ProjectID *uint  `json:"projectId" query:"projectId"`
+	Completed *bool  `json:"completed" query:"completed"`
+	Priority  string `json:"priority" query:"priority" validate:"omitempty,oneof=none low medium high"`
+	Tag       string `json:"tag" query:"tag" validate:"omitempty,max=50"`
+	Archived  string `json:"archived" query:"archived" validate:"omitempty,oneof=include only exclude"`
+	Page      int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit     int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Response DTOs
+// Title dan Snippet sudah di-escape HTML, term yang cocok dibungkus <mark>...</mark>
+type Result struct {
+	TaskID      uint    `json:"taskId"`
+	Title       string  `json:"title"`
+	Snippet     string  `json:"snippet"` // potongan deskripsi di sekitar term pertama yang cocok
+	Score       float64 `json:"score"`
+	ProjectID   *uint   `json:"projectId"`
+	Priority    string  `json:"priority"`
+	IsCompleted bool    `json:"isCompleted"`
+	Archived    bool    `json:"archived"`
+}
+
+type ListResponse struct {
+	Results []Result `json:"results"`
+	Total   int64    `json:"total"`
+	Page    int      `json:"page"`
+	Limit   int      `json:"limit"`
+}
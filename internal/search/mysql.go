@@ -0,0 +1,35 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+// mysqlIndex memakai FULLTEXT index InnoDB pada (title, content)
+// Kata yang lebih pendek dari innodb_ft_min_token_size (default 3) atau termasuk stopword tidak di-index MySQL
+type mysqlIndex struct {
+	table
+}
+
+// Index implements SearchIndex.
+func (i *mysqlIndex) Index(ctx context.Context, doc Document) error {
+	return i.db.WithContext(ctx).
+		Exec("INSERT INTO task_search (task_id, title, content) VALUES (?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE title = VALUES(title), content = VALUES(content)", doc.TaskID, doc.Title, doc.Content).
+		Error
+}
+
+// Search implements SearchIndex.
+func (i *mysqlIndex) Search(ctx context.Context, filter Filter) ([]Hit, int64, error) {
+	match := "MATCH (task_search.title, task_search.content) AGAINST (? IN BOOLEAN MODE)"
+	return i.search(ctx, filter, match, match, mysqlQuery(filter.Terms))
+}
+
+// mysqlQuery membuat query boolean mode agar semua term wajib cocok (+term) sebagai prefix (term*)
+func mysqlQuery(terms []string) string {
+	words := make([]string, len(terms))
+	for n, term := range terms {
+		words[n] = "+" + term + "*"
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+// postgresIndex memakai kolom generated tsvector (judul berbobot A, deskripsi B) dengan GIN index
+// Config 'simple' tidak melakukan stemming sehingga hasilnya sama untuk teks Indonesia maupun Inggris
+type postgresIndex struct {
+	table
+}
+
+// Index implements SearchIndex.
+// Kolom document dihitung ulang otomatis oleh PostgreSQL dari title dan content
+func (i *postgresIndex) Index(ctx context.Context, doc Document) error {
+	return i.db.WithContext(ctx).
+		Exec("INSERT INTO task_search (task_id, title, content) VALUES (?, ?, ?) "+
+			"ON CONFLICT (task_id) DO UPDATE SET title = EXCLUDED.title, content = EXCLUDED.content", doc.TaskID, doc.Title, doc.Content).
+		Error
+}
+
+// Search implements SearchIndex.
+func (i *postgresIndex) Search(ctx context.Context, filter Filter) ([]Hit, int64, error) {
+	return i.search(ctx, filter,
+		"task_search.document @@ to_tsquery('simple', ?)",
+		"ts_rank(task_search.document, to_tsquery('simple', ?))",
+		postgresQuery(filter.Terms))
+}
+
+// postgresQuery menggabungkan semua term dengan AND sebagai prefix: rapat:* & kantor:*
+func postgresQuery(terms []string) string {
+	lexemes := make([]string, len(terms))
+	for n, term := range terms {
+		lexemes[n] = term + ":*"
+	}
+	return strings.Join(lexemes, " & ")
+}
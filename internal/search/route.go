@@ -0,0 +1,14 @@
+package search
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	search := app.Group("/api/search")
+
+	search.Get("/", middlewares.Auth(cfg), ctrl.Search)
+}
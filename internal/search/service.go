@@ -0,0 +1,73 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+// Pagination default untuk hasil pencarian
+const (
+	DefaultLimit = 20
+)
+
+// Service defines the interface for search business logic
+type Service interface {
+	Search(ctx context.Context, userID uint, query *Query) (*ListResponse, error)
+}
+
+// service implements Service.
+type service struct {
+	index SearchIndex
+}
+
+// Search implements Service.
+// Hanya task milik user yang belum di trash yang dicari, task yang diarsipkan ikut jika query.Archived include/only
+func (s *service) Search(ctx context.Context, userID uint, query *Query) (*ListResponse, error) {
+	keywords := terms(query.Q)
+	if len(keywords) == 0 {
+		return nil, ErrInvalidQuery
+	}
+
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+
+	hits, total, err := s.index.Search(ctx, Filter{
+		UserID:    userID,
+		Terms:     keywords,
+		ProjectID: query.ProjectID,
+		Completed: query.Completed,
+		Priority:  query.Priority,
+		Tag:       strings.ToLower(strings.TrimSpace(query.Tag)),
+		Archived:  query.Archived,
+		Offset:    (page - 1) * limit,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, ErrSearchFailed.Wrap(err)
+	}
+
+	results := make([]Result, len(hits))
+	for i, hit := range hits {
+		results[i] = Result{
+			TaskID:      hit.TaskID,
+			Title:       highlight(hit.Title, keywords),
+			Snippet:     snippet(hit.Content, keywords),
+			Score:       hit.Score,
+			ProjectID:   hit.ProjectID,
+			Priority:    hit.Priority,
+			IsCompleted: hit.IsCompleted,
+			Archived:    hit.Archived,
+		}
+	}
+
+	return &ListResponse{Results: results, Total: total, Page: page, Limit: limit}, nil
+}
+
+func NewService(index SearchIndex) Service {
+	return withTracing(&service{index: index})
+}
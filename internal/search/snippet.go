@@ -0,0 +1,117 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Ukuran snippet deskripsi dalam jumlah kata
+const (
+	snippetWords = 24 // jumlah kata maksimal di snippet
+	snippetLead  = 6  // jumlah kata sebelum kata pertama yang cocok
+)
+
+// Penanda kata yang cocok di title dan snippet
+// Highlight dibuat di aplikasi (bukan snippet()/ts_headline() database) agar format dan escaping-nya sama di semua dialect
+const (
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+)
+
+// span adalah posisi byte satu kata di teks
+type span struct {
+	start, end int
+	match      bool
+}
+
+// highlight meng-escape HTML seluruh text dan menandai kata yang diawali salah satu term
+func highlight(text string, terms []string) string {
+	return mark(text, words(text, terms), 0, len(text))
+}
+
+// snippet mengambil potongan text (maksimal snippetWords kata) di sekitar kata pertama yang cocok,
+// atau awal text jika term hanya cocok di judul. Whitespace (termasuk baris baru) diringkas menjadi satu spasi
+func snippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	spans := words(text, terms)
+	if len(spans) == 0 {
+		return ""
+	}
+
+	first := 0
+	for i, s := range spans {
+		if s.match {
+			first = i
+			break
+		}
+	}
+	start := max(first-snippetLead, 0)
+	end := min(start+snippetWords, len(spans))
+	start = max(end-snippetWords, 0)
+
+	from, to := spans[start].start, spans[end-1].end
+	if start == 0 {
+		from = 0
+	}
+	if end == len(spans) {
+		to = len(text)
+	}
+
+	result := mark(text, spans[start:end], from, to)
+	if from > 0 {
+		result = "…" + result
+	}
+	if to < len(text) {
+		result += "…"
+	}
+	return result
+}
+
+// words mencari posisi setiap kata (huruf/angka berurutan) di text
+func words(text string, terms []string) []span {
+	var spans []span
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			spans = append(spans, span{start: start, end: i, match: matches(text[start:i], terms)})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start: start, end: len(text), match: matches(text[start:], terms)})
+	}
+	return spans
+}
+
+// matches mengecek apakah word diawali salah satu term, sama seperti pencocokan prefix di database
+func matches(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// mark meng-escape text[from:to] dan membungkus kata yang cocok dengan markOpen/markClose
+func mark(text string, spans []span, from, to int) string {
+	var b strings.Builder
+	pos := from
+	for _, s := range spans {
+		if !s.match || s.start < from || s.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:s.start]))
+		b.WriteString(markOpen)
+		b.WriteString(html.EscapeString(text[s.start:s.end]))
+		b.WriteString(markClose)
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	return b.String()
+}
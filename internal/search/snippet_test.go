@@ -0,0 +1,154 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		want []string
+	}{
+		{"lowercases words", "Rapat Kantor", []string{"rapat", "kantor"}},
+		{"drops duplicates", "rapat RAPAT Rapat", []string{"rapat"}},
+		{"keeps letters and digits in any script", "Café naïve 2026 日本", []string{"café", "naïve", "2026", "日本"}},
+		{"strips FTS5 syntax", `"rapat"* OR title:kantor NEAR(a b) ^x`, []string{"rapat", "or", "title", "kantor", "near", "a", "b", "x"}},
+		{"strips MySQL boolean operators", `+wajib -tidak ~kurang >lebih <(grup) @3`, []string{"wajib", "tidak", "kurang", "lebih", "grup", "3"}},
+		{"strips tsquery operators", `rapat:* & !kantor | (tim <-> kerja)`, []string{"rapat", "kantor", "tim", "kerja"}},
+		{"strips quotes and backslashes", `it's \"x\" ; DROP`, []string{"it", "s", "x", "drop"}},
+		{"only punctuation", `"*:()&|!`, []string{}},
+		{"empty", "", []string{}},
+		{"limits terms", "a b c d e f g h i j k l", []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}},
+		{"duplicates do not count towards the limit", "a a a a a a a a a a b", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terms(tt.q); !slices.Equal(got, tt.want) {
+				t.Fatalf("terms(%q) = %q, want %q", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"marks words case-insensitively", "Rapat kantor", []string{"rapat"}, "<mark>Rapat</mark> kantor"},
+		{"marks every matching word", "rapat lalu rapat", []string{"rapat"}, "<mark>rapat</mark> lalu <mark>rapat</mark>"},
+		{"matches word prefixes only", "rapatkan trap rap", []string{"rap"}, "<mark>rapatkan</mark> trap <mark>rap</mark>"},
+		{"escapes HTML around and inside marks", `Rapat <b>kantor</b> & "tim"`, []string{"rapat", "kantor"},
+			"<mark>Rapat</mark> &lt;b&gt;<mark>kantor</mark>&lt;/b&gt; &amp; &#34;tim&#34;"},
+		{"escapes script without matches", "<script>alert(1)</script>", []string{"laporan"}, "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"script tag name is a word but stays escaped", "<script>", []string{"script"}, "&lt;<mark>script</mark>&gt;"},
+		{"multibyte text", "Ünïcode résumé — café", []string{"rés", "caf"}, "Ünïcode <mark>résumé</mark> — <mark>café</mark>"},
+		{"no terms", "a & b", nil, "a &amp; b"},
+		{"empty", "", []string{"a"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.text, tt.terms); got != tt.want {
+				t.Fatalf("highlight(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// numbered membuat kata prefix01 sampai prefix<n>, dipisah spasi
+func numbered(prefix string, from, to int) []string {
+	var result []string
+	for i := from; i <= to; i++ {
+		result = append(result, fmt.Sprintf("%s%02d", prefix, i))
+	}
+	return result
+}
+
+// joinMarked menggabungkan kata dengan spasi dan menandai kata yang sama dengan marked
+func joinMarked(words []string, marked string) string {
+	result := make([]string, len(words))
+	for i, word := range words {
+		if word == marked {
+			word = markOpen + word + markClose
+		}
+		result[i] = word
+	}
+	return strings.Join(result, " ")
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Join(numbered("kata", 1, 50), " ")
+	multibyte := strings.Join(numbered("café", 1, 50), " ")
+
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"short text is returned whole", "Bahas laporan bulanan", []string{"laporan"}, "Bahas <mark>laporan</mark> bulanan"},
+		{"window at the start", long, []string{"kata03"}, joinMarked(numbered("kata", 1, 24), "kata03") + "…"},
+		{"window in the middle starts a few words before the match", long, []string{"kata15"},
+			"…" + joinMarked(numbered("kata", 9, 32), "kata15") + "…"},
+		{"window at the end is shifted back to stay full", long, []string{"kata49"}, "…" + joinMarked(numbered("kata", 27, 50), "kata49")},
+		{"only the first match positions the window", long, []string{"kata40", "kata20"},
+			"…" + joinMarked(numbered("kata", 14, 37), "kata20") + "…"},
+		{"no match in the description starts at the beginning", long, []string{"judul"}, strings.Join(numbered("kata", 1, 24), " ") + "…"},
+		{"multibyte words keep byte offsets aligned", multibyte, []string{"café15"},
+			"…" + joinMarked(numbered("café", 9, 32), "café15") + "…"},
+		{"escapes script tags", "Bahas <script>alert(1)</script> laporan", []string{"laporan"},
+			"Bahas &lt;script&gt;alert(1)&lt;/script&gt; <mark>laporan</mark>"},
+		{"escapes text cut at the window edge", strings.Repeat("a<b ", 30), []string{"b"},
+			strings.TrimSuffix(strings.Repeat("a&lt;<mark>b</mark> ", 12), " ") + "…"},
+		{"keeps leading punctuation at the start", "— rapat", []string{"rapat"}, "— <mark>rapat</mark>"},
+		{"collapses whitespace and newlines", "baris\n\n  baru\tdi sini", []string{"baru"}, "baris <mark>baru</mark> di sini"},
+		{"empty", "", []string{"a"}, ""},
+		{"punctuation only", "!!! ???", []string{"a"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippet(tt.text, tt.terms); got != tt.want {
+				t.Fatalf("snippet() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Query full-text setiap dialect dibuat dari hasil terms, sehingga operator dari input user tidak pernah sampai ke database
+func TestDialectQueries(t *testing.T) {
+	tests := []struct {
+		q        string
+		sqlite   string
+		mysql    string
+		postgres string
+	}{
+		{"rapat kantor", `"rapat"* "kantor"*`, "+rapat* +kantor*", "rapat:* & kantor:*"},
+		{"café", `"café"*`, "+café*", "café:*"},
+		{`rapat" OR x:* -kantor`, `"rapat"* "or"* "x"* "kantor"*`, "+rapat* +or* +x* +kantor*", "rapat:* & or:* & x:* & kantor:*"},
+		{`'); DROP TABLE tasks; --`, `"drop"* "table"* "tasks"*`, "+drop* +table* +tasks*", "drop:* & table:* & tasks:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			words := terms(tt.q)
+			if got := sqliteQuery(words); got != tt.sqlite {
+				t.Errorf("sqliteQuery() = %q, want %q", got, tt.sqlite)
+			}
+			if got := mysqlQuery(words); got != tt.mysql {
+				t.Errorf("mysqlQuery() = %q, want %q", got, tt.mysql)
+			}
+			if got := postgresQuery(words); got != tt.postgres {
+				t.Errorf("postgresQuery() = %q, want %q", got, tt.postgres)
+			}
+		})
+	}
+}
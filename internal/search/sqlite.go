@@ -0,0 +1,34 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+// sqliteIndex memakai tabel virtual FTS5, rowid dokumen sama dengan ID task
+// FTS5 tidak mendukung foreign key, dokumen task yang dihapus permanen dibersihkan lewat Remove/Prune
+type sqliteIndex struct {
+	table
+}
+
+// Index implements SearchIndex.
+func (i *sqliteIndex) Index(ctx context.Context, doc Document) error {
+	return i.db.WithContext(ctx).
+		Exec("INSERT OR REPLACE INTO task_search (rowid, title, content) VALUES (?, ?, ?)", doc.TaskID, doc.Title, doc.Content).
+		Error
+}
+
+// Search implements SearchIndex.
+// Skor memakai bm25 dengan bobot judul 10x deskripsi, bm25 bernilai negatif (semakin kecil semakin relevan) sehingga dibalik
+func (i *sqliteIndex) Search(ctx context.Context, filter Filter) ([]Hit, int64, error) {
+	return i.search(ctx, filter, "task_search MATCH ?", "-bm25(task_search, 10.0, 1.0)", sqliteQuery(filter.Terms))
+}
+
+// sqliteQuery meng-quote setiap term lalu mencocokkannya sebagai prefix: "rapat"* "kantor"*
+func sqliteQuery(terms []string) string {
+	phrases := make([]string, len(terms))
+	for n, term := range terms {
+		phrases[n] = `"` + term + `"*`
+	}
+	return strings.Join(phrases, " ")
+}
@@ -0,0 +1,26 @@
+package search
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// Search implements Service.
+// Query tidak dicatat di span karena bisa berisi data pribadi user
+func (t *tracedService) Search(ctx context.Context, userID uint, query *Query) (*ListResponse, error) {
+	ctx, span := tracing.Start(ctx, "search.Search", attribute.Int("user.id", int(userID)))
+	res, err := t.next.Search(ctx, userID, query)
+	tracing.End(span, err)
+	return res, err
+}
@@ -0,0 +1,181 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"rest-api/internal/search"
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/auditlog"
+	"slices"
+	"strings"
+)
+
+// BulkMaxTasks adalah jumlah maksimal task dalam satu request bulk
+const BulkMaxTasks = 500
+
+// BulkUpdate implements Service.
+// Setiap task diproses dengan method Service yang sama seperti endpoint task tunggal (UpdateTask/DeleteTask),
+// sehingga otorisasi, revision dan audit log tetap berlaku per item
+// Task yang gagal karena error client (404/403/400) dilaporkan di hasil dan dilewati,
+// semua perubahan yang berhasil disimpan dalam satu transaksi dan di-rollback jika terjadi error internal
+func (s *service) BulkUpdate(ctx context.Context, userID uint, req *BulkRequest) (*BulkResponse, error) {
+	tag := strings.ToLower(strings.TrimSpace(req.Tag))
+	if (req.Operation == BulkAddTag || req.Operation == BulkRemoveTag) && tag == "" {
+		return nil, ErrBulkTagRequired
+	}
+
+	ids, err := s.bulkTargets(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResponse{Operation: req.Operation, Results: make([]BulkItemResult, 0, len(ids))}
+
+	err = s.transaction(ctx, func(tx *service) error {
+		for _, id := range ids {
+			task, err := tx.bulkApply(ctx, userID, id, req, tag)
+			if err != nil {
+				status := apperror.Status(err)
+				if status >= http.StatusInternalServerError {
+					return err
+				}
+				result.Failed++
+				result.Results = append(result.Results, BulkItemResult{ID: id, Status: status, Code: apperror.Code(err)})
+				continue
+			}
+			result.Succeeded++
+			result.Results = append(result.Results, BulkItemResult{ID: id, Success: true, Status: http.StatusOK, Task: task})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// bulkTargets mengembalikan ID task yang diproses: ids dari request (tanpa duplikat), atau hasil filter
+// Query filter dibatasi BulkMaxTasks+1 agar filter yang terlalu luas ditolak tanpa memuat semua task
+func (s *service) bulkTargets(ctx context.Context, userID uint, req *BulkRequest) ([]uint, error) {
+	if len(req.IDs) > 0 {
+		ids := make([]uint, 0, len(req.IDs))
+		for _, id := range req.IDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	query := req.Filter
+	if query == nil {
+		query = &ListQuery{}
+	}
+	if !req.All && !query.hasConditions() {
+		return nil, ErrBulkFilterEmpty
+	}
+
+	filter, err := s.listFilter(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = BulkMaxTasks + 1
+
+	tasks, err := s.repo.FindAllByUserID(ctx, userID, filter)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+	if len(tasks) > BulkMaxTasks {
+		return nil, ErrBulkTooManyTasks
+	}
+
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids, nil
+}
+
+// hasConditions melaporkan apakah query mempersempit task yang dipilih
+// archived=include, sort dan timezone tidak dihitung karena tidak mengurangi hasil
+func (q *ListQuery) hasConditions() bool {
+	return q.Archived == ArchivedOnly || q.ProjectID != nil || q.Tag != "" || q.Priority != "" ||
+		q.Completed != nil || len(q.Fields) > 0 || strings.TrimSpace(q.Search) != "" || q.Due != "" ||
+		q.DueFrom != "" || q.DueTo != "" || strings.TrimSpace(q.Filter) != ""
+}
+
+// bulkApply menjalankan satu operasi bulk ke satu task
+func (s *service) bulkApply(ctx context.Context, userID, taskID uint, req *BulkRequest, tag string) (*Response, error) {
+	update := &UpdateRequest{}
+
+	switch operation := req.Operation; operation {
+	case BulkDelete:
+		if err := s.DeleteTask(ctx, userID, taskID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	case BulkComplete, BulkReopen:
+		completed := operation == BulkComplete
+		update.IsCompleted = &completed
+	case BulkMove:
+		update.ProjectID = req.ProjectID
+	case BulkSetPriority:
+		update.Priority = &req.Priority
+	case BulkAddTag, BulkRemoveTag:
+		// Tag dibaca setelah task dikunci agar tag yang ditambahkan request lain tidak hilang
+		task, err := s.lockOwned(ctx, userID, taskID)
+		if err != nil {
+			return nil, err
+		}
+		tags := tagNames(task)
+		if operation == BulkAddTag {
+			tags = append(tags, tag)
+		} else {
+			tags = slices.DeleteFunc(tags, func(name string) bool { return name == tag })
+		}
+		update.Tags = &tags
+	}
+
+	return s.UpdateTask(ctx, userID, taskID, update)
+}
+
+// bufferedRecorder menampung audit event selama transaksi (lihat service.transaction) berjalan
+type bufferedRecorder struct {
+	entries []auditlog.Entry
+}
+
+// Record implements auditlog.Recorder.
+func (b *bufferedRecorder) Record(_ context.Context, entry auditlog.Entry) {
+	b.entries = append(b.entries, entry)
+}
+
+// flush meneruskan semua event ke recorder asli
+func (b *bufferedRecorder) flush(ctx context.Context, recorder auditlog.Recorder) {
+	for _, entry := range b.entries {
+		recorder.Record(ctx, entry)
+	}
+}
+
+// bufferedIndex menampung dokumen search index selama transaksi (lihat service.transaction) berjalan
+// Method lain diteruskan ke SearchIndex asli
+type bufferedIndex struct {
+	search.SearchIndex
+	docs []search.Document
+}
+
+// Index implements search.SearchIndex.
+func (b *bufferedIndex) Index(_ context.Context, doc search.Document) error {
+	b.docs = append(b.docs, doc)
+	return nil
+}
+
+// flush menyimpan semua dokumen ke SearchIndex asli, kegagalan hanya dicatat di log seperti indexTask
+func (b *bufferedIndex) flush(ctx context.Context) {
+	for _, doc := range b.docs {
+		if err := b.SearchIndex.Index(ctx, doc); err != nil {
+			slog.WarnContext(ctx, "search index update failed", slog.Any("task_id", doc.TaskID), slog.Any("error", err))
+		}
+	}
+}
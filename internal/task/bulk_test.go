@@ -0,0 +1,54 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBulkUpdateFilterTargets(t *testing.T) {
+	ctx := context.Background()
+	svc, db, userID := newTestService(t)
+
+	tasks := make([]Task, BulkMaxTasks+1)
+	for i := range tasks {
+		tasks[i] = Task{UserID: userID, Title: fmt.Sprintf("task %d", i), Priority: PriorityNone, Position: fmt.Sprintf("a%04d", i)}
+	}
+	if err := db.CreateInBatches(tasks, 100).Error; err != nil {
+		t.Fatalf("create tasks: %v", err)
+	}
+	if err := db.Model(&Task{}).Where("id <= ?", tasks[1].ID).Update("priority", PriorityHigh).Error; err != nil {
+		t.Fatalf("set priority: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  BulkRequest
+		err  error
+	}{
+		{"empty filter", BulkRequest{Filter: &ListQuery{}}, ErrBulkFilterEmpty},
+		{"filter without conditions", BulkRequest{Filter: &ListQuery{Sort: SortDue, Archived: ArchivedInclude, Timezone: "Asia/Jakarta"}}, ErrBulkFilterEmpty},
+		{"blank DSL filter", BulkRequest{Filter: &ListQuery{Filter: "  "}}, ErrBulkFilterEmpty},
+		{"all without filter", BulkRequest{All: true}, ErrBulkTooManyTasks},
+		{"all with empty filter", BulkRequest{All: true, Filter: &ListQuery{}}, ErrBulkTooManyTasks},
+		{"filter matching too many tasks", BulkRequest{Filter: &ListQuery{Filter: "status:open"}}, ErrBulkTooManyTasks},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Operation = BulkComplete
+			if _, err := svc.BulkUpdate(ctx, userID, &tt.req); !errors.Is(err, tt.err) {
+				t.Fatalf("BulkUpdate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	result, err := svc.BulkUpdate(ctx, userID, &BulkRequest{Operation: BulkComplete, Filter: &ListQuery{Priority: PriorityHigh}})
+	if err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+	if result.Succeeded != 2 || result.Failed != 0 {
+		t.Fatalf("BulkUpdate() = %d succeeded, %d failed, want 2 succeeded", result.Succeeded, result.Failed)
+	}
+}
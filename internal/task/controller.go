@@ -3,6 +3,7 @@ package task
 import (
 	"rest-api/internal/auth"
 	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -23,42 +24,51 @@ func NewController(service Service) *Controller {
 // @Produce json
 // @Param data body CreateRequest true "Task data"
 // @Success 201 {object} response.SuccessResponse
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/tasks [post]
 func (ctrl *Controller) CreateTask(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
 
 	var req CreateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid request body")
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	taskResponse, err := ctrl.service.CreateTask(user.ID, req.Title, req.Description)
+	taskResponse, err := ctrl.service.CreateTask(c.UserContext(), user.ID, &req)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusCreated, "Task created successfully", fiber.Map{
+	return response.Success(c, fiber.StatusCreated, "TASK_CREATED", fiber.Map{
 		"task": taskResponse,
 	})
 }
 
 // @Summary List user tasks
-// @Description Get all tasks for current user
+// @Description Get all tasks for current user, task yang diarsipkan tidak ikut kecuali archived=include|only. Filter projectId, tag, priority dan completed bersifat opsional
 // @Tags Tasks
 // @Produce json
+// @Param query query ListQuery false "Filter"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 401 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/tasks [get]
 func (ctrl *Controller) GetTasksByUserID(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
 
-	tasks, err := ctrl.service.GetTasksByUserID(user.ID)
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	tasks, err := ctrl.service.GetTasksByUserID(c.UserContext(), user.ID, &query)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Tasks retrieved successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "TASKS_RETRIEVED", fiber.Map{
 		"tasks": tasks,
 	})
 }
@@ -69,7 +79,7 @@ func (ctrl *Controller) GetTasksByUserID(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path int true "Task ID"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 404 {object} response.ErrorResponse
+// @Failure 404 {object} response.ProblemResponse
 // @Router /api/tasks/{id} [get]
 func (ctrl *Controller) GetTaskByID(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
@@ -77,21 +87,15 @@ func (ctrl *Controller) GetTaskByID(c *fiber.Ctx) error {
 
 	taskID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid task ID")
+		return ErrInvalidTaskID
 	}
 
-	task, err := ctrl.service.GetTaskByID(user.ID, uint(taskID))
+	task, err := ctrl.service.GetTaskByID(c.UserContext(), user.ID, uint(taskID))
 	if err != nil {
-		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "task not found" {
-			statusCode = fiber.StatusNotFound
-		} else if err.Error() == "unauthorized to access this task" {
-			statusCode = fiber.StatusForbidden
-		}
-		return response.Error(c, statusCode, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Task retrieved successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "TASK_RETRIEVED", fiber.Map{
 		"task": task,
 	})
 }
@@ -104,7 +108,10 @@ func (ctrl *Controller) GetTaskByID(c *fiber.Ctx) error {
 // @Param id path int true "Task ID"
 // @Param data body UpdateRequest true "Task data"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/tasks/{id} [put]
 func (ctrl *Controller) UpdateTask(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
@@ -112,39 +119,31 @@ func (ctrl *Controller) UpdateTask(c *fiber.Ctx) error {
 
 	taskID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid task ID")
+		return ErrInvalidTaskID
 	}
 
 	var req UpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid request body")
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	updatedTask, err := ctrl.service.UpdateTask(user.ID, uint(taskID), &req)
+	updatedTask, err := ctrl.service.UpdateTask(c.UserContext(), user.ID, uint(taskID), &req)
 	if err != nil {
-		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "task not found" {
-			statusCode = fiber.StatusNotFound
-		} else if err.Error() == "unauthorized to update this task" {
-			statusCode = fiber.StatusForbidden
-		} else if err.Error() == "title is required" {
-			statusCode = fiber.StatusBadRequest
-		}
-		return response.Error(c, statusCode, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Task updated successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "TASK_UPDATED", fiber.Map{
 		"task": updatedTask,
 	})
 }
 
 // @Summary Delete task
-// @Description Delete a task by ID
+// @Description Pindahkan task ke trash, task bisa di-restore sampai dihapus permanen atau di-purge
 // @Tags Tasks
 // @Produce json
 // @Param id path int true "Task ID"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 404 {object} response.ErrorResponse
+// @Failure 404 {object} response.ProblemResponse
 // @Router /api/tasks/{id} [delete]
 func (ctrl *Controller) DeleteTask(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
@@ -152,18 +151,392 @@ func (ctrl *Controller) DeleteTask(c *fiber.Ctx) error {
 
 	taskID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid task ID")
+		return ErrInvalidTaskID
+	}
+
+	if err := ctrl.service.DeleteTask(c.UserContext(), user.ID, uint(taskID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_DELETED", fiber.Map{})
+}
+
+// @Summary Get task history
+// @Description Riwayat perubahan task per field, diurutkan dari revision terlama
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse{data=object{history=[]RevisionResponse}}
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/history [get]
+func (ctrl *Controller) GetTaskHistory(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	history, err := ctrl.service.GetTaskHistory(c.UserContext(), user.ID, uint(taskID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_HISTORY_RETRIEVED", fiber.Map{
+		"history": history,
+	})
+}
+
+// @Summary Revert task
+// @Description Kembalikan task ke state setelah revision tertentu (0 = state saat dibuat). Revert dicatat sebagai revision baru
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/revert/{revision} [post]
+func (ctrl *Controller) RevertTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	revision, err := strconv.Atoi(c.Params("revision"))
+	if err != nil || revision < 0 {
+		return ErrInvalidRevision
+	}
+
+	task, err := ctrl.service.RevertTask(c.UserContext(), user.ID, uint(taskID), revision)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_REVERTED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary List trash
+// @Description Task yang sudah dihapus dan masih bisa di-restore, diurutkan dari yang terakhir dihapus
+// @Tags Tasks
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/tasks/trash [get]
+func (ctrl *Controller) GetTrash(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	tasks, err := ctrl.service.GetTrash(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TRASH_RETRIEVED", fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+// @Summary Restore task
+// @Description Kembalikan task dari trash
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/restore [post]
+func (ctrl *Controller) RestoreTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	task, err := ctrl.service.RestoreTask(c.UserContext(), user.ID, uint(taskID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_RESTORED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Delete task permanently
+// @Description Hapus permanen task yang ada di trash beserta riwayatnya
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/trash/{id} [delete]
+func (ctrl *Controller) DeleteTaskPermanently(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	if err := ctrl.service.DeleteTaskPermanently(c.UserContext(), user.ID, uint(taskID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_PURGED", fiber.Map{})
+}
+
+// @Summary Archive task
+// @Description Arsipkan task tanpa mengubah status selesai
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/archive [post]
+func (ctrl *Controller) ArchiveTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	task, err := ctrl.service.ArchiveTask(c.UserContext(), user.ID, uint(taskID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_ARCHIVED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Unarchive task
+// @Description Keluarkan task dari arsip
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/unarchive [post]
+func (ctrl *Controller) UnarchiveTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	task, err := ctrl.service.UnarchiveTask(c.UserContext(), user.ID, uint(taskID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_UNARCHIVED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Bulk unarchive tasks
+// @Description Keluarkan beberapa task (ids) atau semua task (all=true) dari arsip. ID milik user lain diabaikan
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param data body UnarchiveRequest true "Task IDs"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/tasks/unarchive [post]
+func (ctrl *Controller) UnarchiveTasks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req UnarchiveRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	count, err := ctrl.service.UnarchiveTasks(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASKS_UNARCHIVED", fiber.Map{
+		"count": count,
+	})
+}
+
+// @Summary Bulk task operation
+// @Description Jalankan satu operasi (complete, reopen, delete, move, add_tag, remove_tag, set_priority) ke task yang dipilih lewat ids atau filter.
+// @Description Otorisasi diterapkan per task, task yang gagal dilaporkan di results dan tidak membatalkan task lain.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param data body BulkRequest true "Bulk operation"
+// @Success 200 {object} response.SuccessResponse{data=BulkResponse}
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/tasks/bulk [post]
+func (ctrl *Controller) BulkUpdate(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req BulkRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	result, err := ctrl.service.BulkUpdate(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASKS_BULK_UPDATED", result)
+}
+
+// @Summary Move task
+// @Description Pindahkan task pada urutan manual (sort=position), tepat setelah afterId dan/atau tepat sebelum beforeId.
+// @Description Hanya posisi task yang dipindahkan yang berubah.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param data body MoveRequest true "Anchor task"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/move [post]
+func (ctrl *Controller) MoveTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	var req MoveRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	task, err := ctrl.service.MoveTask(c.UserContext(), user.ID, uint(taskID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_MOVED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Add task dependency
+// @Description Tandai task diblokir oleh task lain (blockedById). Ditolak jika membentuk siklus dependency.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param data body DependencyRequest true "Blocker task"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/dependencies [post]
+func (ctrl *Controller) AddDependency(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
 	}
 
-	if err := ctrl.service.DeleteTask(user.ID, uint(taskID)); err != nil {
-		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "task not found" {
-			statusCode = fiber.StatusNotFound
-		} else if err.Error() == "unauthorized to delete this task" {
-			statusCode = fiber.StatusForbidden
-		}
-		return response.Error(c, statusCode, err.Error())
+	var req DependencyRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Task deleted successfully", fiber.Map{})
-}
\ No newline at end of file
+	task, err := ctrl.service.AddDependency(c.UserContext(), user.ID, uint(taskID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_DEPENDENCY_ADDED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Remove task dependency
+// @Description Hapus dependency task ke blocker-nya
+// @Tags Tasks
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param blockedById path int true "Blocker task ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/tasks/{id}/dependencies/{blockedById} [delete]
+func (ctrl *Controller) RemoveDependency(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	taskID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+	blockedByID, err := strconv.ParseUint(c.Params("blockedById"), 10, 32)
+	if err != nil {
+		return ErrInvalidTaskID
+	}
+
+	task, err := ctrl.service.RemoveDependency(c.UserContext(), user.ID, uint(taskID), uint(blockedByID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASK_DEPENDENCY_REMOVED", fiber.Map{
+		"task": task,
+	})
+}
+
+// @Summary Dependency order
+// @Description Task di project dalam urutan topologis: setiap task muncul setelah semua blocker-nya. Task yang tidak saling bergantung mengikuti urutan manual.
+// @Tags Tasks
+// @Produce json
+// @Param query query DependencyOrderQuery true "Project"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/tasks/dependency-order [get]
+func (ctrl *Controller) GetDependencyOrder(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query DependencyOrderQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	tasks, err := ctrl.service.GetDependencyOrder(c.UserContext(), user.ID, query.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASKS_RETRIEVED", fiber.Map{
+		"tasks": tasks,
+	})
+}
@@ -0,0 +1,170 @@
+package task
+
+import (
+	"context"
+	"rest-api/internal/customfield"
+	"slices"
+	"strings"
+)
+
+// applyFields mengisi nilai custom field dari request ke task, hanya key yang dikirim yang diubah
+// Nilai null atau string kosong menghapus nilai field dari task
+// Field harus milik user dan berlaku untuk project task (lihat customfield.Field.AppliesTo)
+func (s *service) applyFields(ctx context.Context, task *Task, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	fields, err := s.userFields(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	for key, raw := range values {
+		field, ok := fields[key]
+		if !ok || !field.AppliesTo(task.ProjectID) {
+			return customfield.ErrUnknownField.WithParams(map[string]string{"field": key})
+		}
+		value, err := field.Normalize(raw)
+		if err != nil {
+			return err
+		}
+		setFieldValue(task, field, value)
+	}
+	return nil
+}
+
+// restoreFields mengembalikan nilai custom field dari snapshot revision
+// Field yang sudah dihapus atau tidak berlaku lagi untuk project task dilewati
+func (s *service) restoreFields(ctx context.Context, task *Task, state interface{}) error {
+	values := map[string]string{}
+	switch snapshotValues := state.(type) {
+	case map[string]string:
+		values = snapshotValues
+	case map[string]interface{}:
+		for key, value := range snapshotValues {
+			if text, ok := value.(string); ok {
+				values[key] = text
+			}
+		}
+	}
+
+	fields, err := s.userFields(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	task.Fields = nil
+	for key, text := range values {
+		field, ok := fields[key]
+		if !ok || !field.AppliesTo(task.ProjectID) {
+			continue
+		}
+		value, err := field.Parse(text)
+		if err != nil {
+			continue
+		}
+		setFieldValue(task, field, value)
+	}
+	return nil
+}
+
+// dropInapplicableFields menghapus nilai field milik project lain, dipanggil setelah project task berubah
+func dropInapplicableFields(task *Task) {
+	task.Fields = slices.DeleteFunc(task.Fields, func(value FieldValue) bool {
+		return value.Field != nil && !value.Field.AppliesTo(task.ProjectID)
+	})
+}
+
+// applyFieldQuery me-resolve key custom field di filter dan sort query list ke ID field
+func (s *service) applyFieldQuery(ctx context.Context, userID uint, q *ListQuery, filter *Filter) error {
+	if len(q.Fields) == 0 && q.Sort != SortField {
+		return nil
+	}
+
+	fields, err := s.userFields(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range q.Fields {
+		key, raw, ok := strings.Cut(condition, ":")
+		if !ok {
+			return ErrInvalidFieldFilter
+		}
+		field, ok := fields[strings.TrimSpace(key)]
+		if !ok {
+			return customfield.ErrUnknownField.WithParams(map[string]string{"field": key})
+		}
+		value, err := field.Parse(raw)
+		if err != nil {
+			return err
+		}
+		filter.Fields = append(filter.Fields, FieldFilter{FieldID: field.ID, Value: value.Text})
+	}
+
+	if q.Sort == SortField {
+		field, ok := fields[q.SortField]
+		if !ok {
+			return customfield.ErrUnknownField.WithParams(map[string]string{"field": q.SortField})
+		}
+		filter.FieldSort = &FieldSort{
+			FieldID: field.ID,
+			Numeric: field.Type == customfield.TypeNumber,
+			Desc:    q.Order == OrderDesc,
+		}
+	}
+	return nil
+}
+
+// userFields mengambil definisi custom field user, di-index berdasarkan key
+func (s *service) userFields(ctx context.Context, userID uint) (map[string]*customfield.Field, error) {
+	fields, err := s.repo.FindFields(ctx, userID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	byKey := make(map[string]*customfield.Field, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	return byKey, nil
+}
+
+// setFieldValue mengganti nilai satu field pada task, value nil menghapus nilainya
+func setFieldValue(task *Task, field *customfield.Field, value *customfield.Value) {
+	task.Fields = slices.DeleteFunc(task.Fields, func(current FieldValue) bool {
+		return current.FieldID == field.ID
+	})
+	if value == nil {
+		return
+	}
+	task.Fields = append(task.Fields, FieldValue{
+		FieldID: field.ID,
+		Value:   value.Text,
+		Number:  value.Number,
+		Field:   field,
+	})
+}
+
+// fieldTexts mengembalikan nilai kanonik custom field task per key untuk snapshot revision
+func fieldTexts(task *Task) map[string]string {
+	texts := make(map[string]string, len(task.Fields))
+	for _, value := range task.Fields {
+		if value.Field != nil {
+			texts[value.Field.Key] = value.Value
+		}
+	}
+	return texts
+}
+
+// fieldValues mengembalikan nilai custom field task per key dalam bentuk JSON, selalu non-nil
+func fieldValues(task *Task) map[string]interface{} {
+	values := make(map[string]interface{}, len(task.Fields))
+	for _, value := range task.Fields {
+		if value.Field != nil {
+			values[value.Field.Key] = value.Field.Decode(value.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,221 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"rest-api/pkg/auditlog"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// AddDependency implements Service.
+// Dependency ditolak jika membentuk siklus, contoh: A diblokir B sementara B (langsung atau tidak) diblokir A
+// Pengecekan siklus dan insert berjalan dalam satu transaksi yang mengunci user, sehingga dua dependency yang
+// ditambahkan bersamaan (A diblokir B dan B diblokir A) tidak bisa sama-sama lolos
+func (s *service) AddDependency(ctx context.Context, userID, taskID uint, req *DependencyRequest) (*Response, error) {
+	var res *Response
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		res, err = tx.addDependency(ctx, userID, taskID, req)
+		return err
+	})
+	return res, err
+}
+
+// addDependency menambah dependency, harus dipanggil di dalam transaksi (lihat AddDependency)
+func (s *service) addDependency(ctx context.Context, userID, taskID uint, req *DependencyRequest) (*Response, error) {
+	// Graph dependency milik satu user, jadi user dikunci sebelum dependency dibaca
+	if err := s.repo.LockUser(ctx, userID); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if req.BlockedByID == taskID {
+		return nil, ErrDependencyCycle
+	}
+	if _, err := s.findOwned(ctx, userID, req.BlockedByID); err != nil {
+		return nil, err
+	}
+
+	before := blockerIDs(task)
+	if slices.Contains(before, req.BlockedByID) {
+		return toResponse(task), nil
+	}
+
+	dependencies, err := s.repo.FindDependencies(ctx, userID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+	if createsCycle(dependencies, taskID, req.BlockedByID) {
+		return nil, ErrDependencyCycle
+	}
+
+	if err := s.repo.AddDependency(ctx, &Dependency{TaskID: taskID, BlockedByID: req.BlockedByID}); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+
+	return s.recordDependencies(ctx, userID, taskID, before)
+}
+
+// RemoveDependency implements Service.
+func (s *service) RemoveDependency(ctx context.Context, userID, taskID, blockedByID uint) (*Response, error) {
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.repo.RemoveDependency(ctx, taskID, blockedByID)
+	if err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	if removed == 0 {
+		return nil, ErrDependencyNotFound
+	}
+
+	return s.recordDependencies(ctx, userID, taskID, blockerIDs(task))
+}
+
+// GetDependencyOrder implements Service.
+// Task di project diurutkan sehingga setiap task muncul setelah semua blocker-nya (Kahn's algorithm)
+// Task yang tidak saling bergantung mengikuti urutan manual (position), dependency ke task di luar project diabaikan
+func (s *service) GetDependencyOrder(ctx context.Context, userID, projectID uint) ([]Response, error) {
+	exists, err := s.repo.ProjectExists(ctx, userID, projectID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+	if !exists {
+		return nil, ErrProjectNotFound
+	}
+	if err := s.ensureRanked(ctx, userID); err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	tasks, err := s.repo.FindAllByUserID(ctx, userID, Filter{ProjectID: &projectID, Sort: SortPosition})
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	index := make(map[uint]int, len(tasks))
+	for i := range tasks {
+		index[tasks[i].ID] = i
+	}
+
+	// pending menghitung blocker di dalam project yang belum masuk urutan
+	pending := make([]int, len(tasks))
+	for i := range tasks {
+		for _, dependency := range tasks[i].BlockedBy {
+			if _, ok := index[dependency.BlockedByID]; ok {
+				pending[i]++
+			}
+		}
+	}
+
+	responses := make([]Response, 0, len(tasks))
+	done := make([]bool, len(tasks))
+	for len(responses) < len(tasks) {
+		// Ambil task pertama (urutan position) yang semua blocker-nya sudah masuk urutan
+		// Jika tidak ada (siklus dari data lama), task pertama yang tersisa tetap diambil agar semua task dikembalikan
+		next := -1
+		for i := range tasks {
+			if !done[i] && (pending[i] == 0 || next < 0) {
+				next = i
+				if pending[i] == 0 {
+					break
+				}
+			}
+		}
+
+		done[next] = true
+		responses = append(responses, *toResponse(&tasks[next]))
+		for _, dependency := range tasks[next].Blocks {
+			if i, ok := index[dependency.TaskID]; ok && !done[i] {
+				pending[i]--
+			}
+		}
+	}
+
+	return responses, nil
+}
+
+// checkBlockers menolak penyelesaian task yang masih punya blocker belum selesai
+// Hanya berlaku jika DEPENDENCY_BLOCKS_COMPLETION aktif
+func (s *service) checkBlockers(ctx context.Context, taskID uint) error {
+	if !s.blockCompletion {
+		return nil
+	}
+
+	open, err := s.repo.CountOpenBlockers(ctx, taskID)
+	if err != nil {
+		return ErrTaskFetchFailed.Wrap(err)
+	}
+	if open > 0 {
+		return ErrTaskBlocked
+	}
+	return nil
+}
+
+// recordDependencies mengambil ulang task setelah dependency berubah dan mencatatnya di audit log
+// Dependency tidak disimpan sebagai revision sehingga tidak ikut di-revert
+func (s *service) recordDependencies(ctx context.Context, userID, taskID uint, before []uint) (*Response, error) {
+	task, err := s.repo.FindByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	entry := auditlog.Target(auditlog.ActionTaskUpdate, auditlog.TargetTask, task.ID)
+	entry.Changes = auditlog.Changes{"blockedBy": {From: before, To: blockerIDs(task)}}
+	s.audit.Record(ctx, entry)
+
+	return toResponse(task), nil
+}
+
+// createsCycle bernilai true jika menambahkan taskID diblokir blockedByID membentuk siklus,
+// yaitu jika taskID sudah bisa dicapai dari blockedByID lewat rantai blocker
+func createsCycle(dependencies []Dependency, taskID, blockedByID uint) bool {
+	blockers := make(map[uint][]uint, len(dependencies))
+	for _, dependency := range dependencies {
+		blockers[dependency.TaskID] = append(blockers[dependency.TaskID], dependency.BlockedByID)
+	}
+
+	visited := map[uint]bool{}
+	stack := []uint{blockedByID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == taskID {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		stack = append(stack, blockers[current]...)
+	}
+	return false
+}
+
+// blockerIDs mengembalikan ID task yang memblokir task, selalu non-nil agar konsisten di JSON
+func blockerIDs(task *Task) []uint {
+	ids := make([]uint, len(task.BlockedBy))
+	for i, dependency := range task.BlockedBy {
+		ids[i] = dependency.BlockedByID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// blockedIDs mengembalikan ID task yang diblokir task
+func blockedIDs(task *Task) []uint {
+	ids := make([]uint, len(task.Blocks))
+	for i, dependency := range task.Blocks {
+		ids[i] = dependency.TaskID
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCreatesCycle(t *testing.T) {
+	// 1 diblokir 2, 2 diblokir 3
+	dependencies := []Dependency{{TaskID: 1, BlockedByID: 2}, {TaskID: 2, BlockedByID: 3}}
+
+	tests := []struct {
+		name                string
+		taskID, blockedByID uint
+		want                bool
+	}{
+		{"direct", 2, 1, true},
+		{"transitive", 3, 1, true},
+		{"independent", 4, 1, false},
+		{"same direction", 1, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createsCycle(dependencies, tt.taskID, tt.blockedByID); got != tt.want {
+				t.Fatalf("createsCycle(%d blocked by %d) = %v, want %v", tt.taskID, tt.blockedByID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddDependencyRejectsConcurrentCycle(t *testing.T) {
+	ctx := context.Background()
+	svc, _, userID := newTestService(t)
+
+	for round := range 50 {
+		var ids [2]uint
+		for i := range ids {
+			res, err := svc.CreateTask(ctx, userID, &CreateRequest{Title: fmt.Sprintf("round %d task %d", round, i)})
+			if err != nil {
+				t.Fatalf("CreateTask() error = %v", err)
+			}
+			ids[i] = res.ID
+		}
+
+		// A diblokir B dan B diblokir A ditambahkan bersamaan, hanya satu yang boleh berhasil
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		var errs [2]error
+		for i := range ids {
+			wg.Go(func() {
+				<-start
+				_, errs[i] = svc.AddDependency(ctx, userID, ids[i], &DependencyRequest{BlockedByID: ids[1-i]})
+			})
+		}
+		close(start)
+		wg.Wait()
+
+		succeeded, cycles := 0, 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, ErrDependencyCycle):
+				cycles++
+			default:
+				t.Fatalf("round %d: AddDependency() error = %v", round, err)
+			}
+		}
+		if succeeded != 1 || cycles != 1 {
+			t.Fatalf("round %d: %d succeeded and %d rejected as cycle, want 1 and 1", round, succeeded, cycles)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package task
+
+import (
+	"errors"
+	"regexp"
+	"rest-api/pkg/filterql"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field yang bisa dipakai di filter DSL (query filter)
+const filterFields = "status, priority, tag, project, due, created, title"
+
+// priorityOrder adalah urutan prioritas untuk operator <, <=, >, >= pada field priority
+var priorityOrder = []string{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh}
+
+// relativeDay mencocokkan hari relatif terhadap hari ini, contoh: 7d, -2w
+var relativeDay = regexp.MustCompile(`^([+-]?\d{1,4})([dw])$`)
+
+// compileFilter mem-parse filter DSL lalu memvalidasi dan me-resolve setiap perbandingan menjadi FilterExpr
+// Tanggal (today, 2026-01-31, 7d) dihitung sebagai satu hari penuh di timezone loc
+func compileFilter(input string, now time.Time, loc *time.Location) (*FilterExpr, error) {
+	node, err := filterql.Parse(input)
+	if err != nil {
+		return nil, invalidFilter(err)
+	}
+
+	c := &filterCompiler{today: startOfDay(now.In(loc))}
+	expr, err := c.compile(node)
+	if err != nil {
+		return nil, invalidFilter(err)
+	}
+	return &expr, nil
+}
+
+// ValidateFilter memeriksa syntax dan field filter DSL tanpa menjalankan query, dipakai saat filter disimpan (contoh: saved view)
+func ValidateFilter(input string) error {
+	if input == "" {
+		return nil
+	}
+	_, err := compileFilter(input, time.Now(), time.UTC)
+	return err
+}
+
+// invalidFilter mengubah *filterql.Error menjadi ErrInvalidFilter beserta posisi error
+func invalidFilter(err error) error {
+	var filterErr *filterql.Error
+	if !errors.As(err, &filterErr) {
+		return ErrInvalidFilter.Wrap(err)
+	}
+	return ErrInvalidFilter.
+		WithParams(map[string]string{"position": strconv.Itoa(filterErr.Position), "reason": filterErr.Message}).
+		WithDetails([]*filterql.Error{filterErr})
+}
+
+type filterCompiler struct {
+	today time.Time
+}
+
+func (c *filterCompiler) compile(node filterql.Node) (FilterExpr, error) {
+	switch n := node.(type) {
+	case *filterql.Logical:
+		left, err := c.compile(n.Left)
+		if err != nil {
+			return FilterExpr{}, err
+		}
+		right, err := c.compile(n.Right)
+		if err != nil {
+			return FilterExpr{}, err
+		}
+		op := FilterAnd
+		if n.Op == filterql.Or {
+			op = FilterOr
+		}
+		return FilterExpr{Op: op, Args: []FilterExpr{left, right}}, nil
+	case *filterql.Not:
+		expr, err := c.compile(n.Expr)
+		if err != nil {
+			return FilterExpr{}, err
+		}
+		return FilterExpr{Op: FilterNot, Args: []FilterExpr{expr}}, nil
+	case *filterql.Comparison:
+		return c.comparison(n)
+	}
+	return FilterExpr{}, filterql.Errorf(node.Position(), "unsupported expression")
+}
+
+func (c *filterCompiler) comparison(n *filterql.Comparison) (FilterExpr, error) {
+	field := strings.ToLower(n.Field)
+	value := strings.ToLower(strings.TrimSpace(n.Value))
+
+	switch field {
+	case "status":
+		if err := allowOps(n, filterql.OpMatch, filterql.OpEqual, filterql.OpNotEqual); err != nil {
+			return FilterExpr{}, err
+		}
+		if value != "open" && value != "completed" {
+			return FilterExpr{}, filterql.Errorf(n.ValuePos, "invalid status %q, expected open or completed", n.Value)
+		}
+		return FilterExpr{Op: equality(n.Op), Field: field, Value: value == "completed"}, nil
+
+	case "priority":
+		target := slices.Index(priorityOrder, value)
+		if target < 0 {
+			return FilterExpr{}, filterql.Errorf(n.ValuePos, "invalid priority %q, expected none, low, medium or high", n.Value)
+		}
+		if n.Op == filterql.OpMatch || n.Op == filterql.OpEqual || n.Op == filterql.OpNotEqual {
+			return FilterExpr{Op: equality(n.Op), Field: field, Value: value}, nil
+		}
+		var priorities []string
+		for i, priority := range priorityOrder {
+			if compareOrder(n.Op, i, target) {
+				priorities = append(priorities, priority)
+			}
+		}
+		return FilterExpr{Op: FilterIn, Field: field, Value: priorities}, nil
+
+	case "tag":
+		if err := allowOps(n, filterql.OpMatch, filterql.OpEqual, filterql.OpNotEqual); err != nil {
+			return FilterExpr{}, err
+		}
+		if value == "" {
+			return FilterExpr{}, filterql.Errorf(n.ValuePos, "tag must not be empty")
+		}
+		return FilterExpr{Op: equality(n.Op), Field: field, Value: value}, nil
+
+	case "project":
+		if err := allowOps(n, filterql.OpMatch, filterql.OpEqual, filterql.OpNotEqual); err != nil {
+			return FilterExpr{}, err
+		}
+		if value == "none" {
+			return FilterExpr{Op: equality(n.Op), Field: field}, nil
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return FilterExpr{}, filterql.Errorf(n.ValuePos, "invalid project %q, expected a project ID or none", n.Value)
+		}
+		return FilterExpr{Op: equality(n.Op), Field: field, Value: uint(id)}, nil
+
+	case "due", "created":
+		return c.date(n, field, value)
+
+	case "title":
+		if err := allowOps(n, filterql.OpMatch, filterql.OpNotEqual); err != nil {
+			return FilterExpr{}, err
+		}
+		if n.Op == filterql.OpNotEqual {
+			return FilterExpr{Op: FilterNotContains, Field: field, Value: n.Value}, nil
+		}
+		return FilterExpr{Op: FilterContains, Field: field, Value: n.Value}, nil
+	}
+
+	return FilterExpr{}, filterql.Errorf(n.Pos, "unknown field %q, expected one of %s", n.Field, filterFields)
+}
+
+// date membandingkan kolom waktu dengan satu hari penuh [start, end)
+// Contoh: due<7d berarti tenggat sebelum hari ke-7 dari hari ini, due:today berarti tenggat hari ini
+// due:none dan due!=none mencocokkan task tanpa/dengan tenggat; due!=<tanggal> ikut mencocokkan task tanpa tenggat
+func (c *filterCompiler) date(n *filterql.Comparison, field, value string) (FilterExpr, error) {
+	if value == "none" {
+		if field != "due" {
+			return FilterExpr{}, filterql.Errorf(n.ValuePos, "%s cannot be none", field)
+		}
+		if err := allowOps(n, filterql.OpMatch, filterql.OpEqual, filterql.OpNotEqual); err != nil {
+			return FilterExpr{}, err
+		}
+		return FilterExpr{Op: equality(n.Op), Field: field}, nil
+	}
+
+	start, ok := c.day(value)
+	if !ok {
+		return FilterExpr{}, filterql.Errorf(n.ValuePos,
+			"invalid date %q, expected YYYY-MM-DD, today, tomorrow, yesterday or relative days such as 7d or -2w", n.Value)
+	}
+	end := start.AddDate(0, 0, 1)
+	start, end = start.UTC(), end.UTC()
+
+	switch n.Op {
+	case filterql.OpLess:
+		return FilterExpr{Op: "<", Field: field, Value: start}, nil
+	case filterql.OpLessEqual:
+		return FilterExpr{Op: "<", Field: field, Value: end}, nil
+	case filterql.OpGreater:
+		return FilterExpr{Op: ">=", Field: field, Value: end}, nil
+	case filterql.OpGreaterEqual:
+		return FilterExpr{Op: ">=", Field: field, Value: start}, nil
+	case filterql.OpNotEqual:
+		outside := []FilterExpr{{Op: "<", Field: field, Value: start}, {Op: ">=", Field: field, Value: end}}
+		if field == "due" {
+			outside = append(outside, FilterExpr{Op: "=", Field: field})
+		}
+		return FilterExpr{Op: FilterOr, Args: outside}, nil
+	}
+	return FilterExpr{Op: FilterAnd, Args: []FilterExpr{
+		{Op: ">=", Field: field, Value: start},
+		{Op: "<", Field: field, Value: end},
+	}}, nil
+}
+
+// day mengembalikan awal hari dari nilai tanggal DSL
+func (c *filterCompiler) day(value string) (time.Time, bool) {
+	switch value {
+	case "today":
+		return c.today, true
+	case "tomorrow":
+		return c.today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return c.today.AddDate(0, 0, -1), true
+	}
+
+	if match := relativeDay.FindStringSubmatch(value); match != nil {
+		days, _ := strconv.Atoi(match[1])
+		if match[2] == "w" {
+			days *= 7
+		}
+		return c.today.AddDate(0, 0, days), true
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, c.today.Location())
+	return date, err == nil
+}
+
+// allowOps menolak operator yang tidak didukung oleh field
+func allowOps(n *filterql.Comparison, ops ...string) error {
+	if slices.Contains(ops, n.Op) {
+		return nil
+	}
+	return filterql.Errorf(n.OpPos, "operator %q is not supported for %s, use one of %s", n.Op, strings.ToLower(n.Field), strings.Join(ops, " "))
+}
+
+// equality memetakan operator : dan = ke "=", != tetap "!="
+func equality(op string) string {
+	if op == filterql.OpNotEqual {
+		return "!="
+	}
+	return "="
+}
+
+// compareOrder membandingkan posisi dua nilai berurutan sesuai operator
+func compareOrder(op string, a, b int) bool {
+	switch op {
+	case filterql.OpLess:
+		return a < b
+	case filterql.OpLessEqual:
+		return a <= b
+	case filterql.OpGreater:
+		return a > b
+	case filterql.OpGreaterEqual:
+		return a >= b
+	}
+	return a == b
+}
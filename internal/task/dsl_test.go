@@ -0,0 +1,80 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"rest-api/pkg/apperror"
+)
+
+func TestCompileFilterDates(t *testing.T) {
+	// 00:30 di Jakarta sudah tanggal 10 Maret, walaupun di UTC masih 9 Maret
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	now := time.Date(2026, 3, 9, 17, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, jakarta).UTC() }
+
+	tests := []struct {
+		input string
+		want  FilterExpr
+	}{
+		{"due:today", FilterExpr{Op: FilterAnd, Args: []FilterExpr{{Op: ">=", Field: "due", Value: day(10)}, {Op: "<", Field: "due", Value: day(11)}}}},
+		{"due<tomorrow", FilterExpr{Op: "<", Field: "due", Value: day(11)}},
+		{"due<=yesterday", FilterExpr{Op: "<", Field: "due", Value: day(10)}},
+		{"due<7d", FilterExpr{Op: "<", Field: "due", Value: day(17)}},
+		{"due>-2w", FilterExpr{Op: ">=", Field: "due", Value: time.Date(2026, 2, 25, 0, 0, 0, 0, jakarta).UTC()}},
+		{"created>=+1d", FilterExpr{Op: ">=", Field: "created", Value: day(11)}},
+		{"due>2026-03-31", FilterExpr{Op: ">=", Field: "due", Value: time.Date(2026, 4, 1, 0, 0, 0, 0, jakarta).UTC()}},
+		{"due!=today", FilterExpr{Op: FilterOr, Args: []FilterExpr{
+			{Op: "<", Field: "due", Value: day(10)}, {Op: ">=", Field: "due", Value: day(11)}, {Op: "=", Field: "due"}}}},
+		{"due:none", FilterExpr{Op: "=", Field: "due"}},
+		{"project!=5", FilterExpr{Op: "!=", Field: "project", Value: uint(5)}},
+		{"priority>medium", FilterExpr{Op: FilterIn, Field: "priority", Value: []string{PriorityHigh}}},
+		{"NOT status:open", FilterExpr{Op: FilterNot, Args: []FilterExpr{{Op: "=", Field: "status", Value: false}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := compileFilter(tt.input, now, jakarta)
+			if err != nil {
+				t.Fatalf("compileFilter(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("compileFilter(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileFilterErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		position string
+	}{
+		{"status:done", "8"},
+		{"priority:urgent", "10"},
+		{"tag>bug", "4"},
+		{`tag:""`, "5"},
+		{"project:abc", "9"},
+		{"due<someday", "5"},
+		{"due<99999d", "5"},
+		{"created:none", "9"},
+		{"title>rapat", "6"},
+		{"status:open OR owner:me", "16"},
+		{"status:open OR", "15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := compileFilter(tt.input, time.Now(), time.UTC)
+			var appErr *apperror.Error
+			if !errors.Is(err, ErrInvalidFilter) || !errors.As(err, &appErr) {
+				t.Fatalf("compileFilter(%q) error = %v, want %v", tt.input, err, ErrInvalidFilter)
+			}
+			if got := appErr.Params["position"]; got != tt.position {
+				t.Fatalf("compileFilter(%q) position = %s (%s), want %s", tt.input, got, appErr.Params["reason"], tt.position)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package task
+
+import "time"
+
+// applyDue mengisi rentang tenggat Filter dari dueFrom/dueTo dan filter due relatif
+// Hari dihitung di timezone q.Timezone (default UTC), rentang absolut dan relatif digabung (irisan)
+func applyDue(q *ListQuery, filter *Filter, now time.Time) {
+	if from, err := time.Parse(time.RFC3339, q.DueFrom); err == nil {
+		narrowDue(filter, &from, nil)
+	}
+	if to, err := time.Parse(time.RFC3339, q.DueTo); err == nil {
+		narrowDue(filter, nil, &to)
+	}
+
+	now = now.In(q.location())
+	today := startOfDay(now)
+
+	switch q.Due {
+	case DueToday:
+		tomorrow := today.AddDate(0, 0, 1)
+		narrowDue(filter, &today, &tomorrow)
+	case DueNext7Days:
+		end := today.AddDate(0, 0, 7)
+		narrowDue(filter, &today, &end)
+	case DueOverdue:
+		narrowDue(filter, nil, &now)
+		completed := false
+		filter.Completed = &completed
+	case DueNone:
+		filter.NoDue = true
+	}
+}
+
+// location mengembalikan timezone query untuk menghitung batas hari, default UTC
+// Nama timezone sudah divalidasi oleh tag timezone di ListQuery
+func (q *ListQuery) location() *time.Location {
+	location, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// startOfDay mengembalikan jam 00:00 di hari dan timezone t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// narrowDue mempersempit rentang tenggat filter, nil berarti batas tersebut tidak diubah
+func narrowDue(filter *Filter, from, to *time.Time) {
+	if from != nil && (filter.DueFrom == nil || from.After(*filter.DueFrom)) {
+		value := from.UTC()
+		filter.DueFrom = &value
+	}
+	if to != nil && (filter.DueTo == nil || to.Before(*filter.DueTo)) {
+		value := to.UTC()
+		filter.DueTo = &value
+	}
+}
+
+// parseDue membaca tenggat dari request, string kosong berarti tanpa tenggat
+// Format sudah divalidasi oleh tag datetime di request
+func parseDue(value string) *time.Time {
+	due, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	due = due.UTC()
+	return &due
+}
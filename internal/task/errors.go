@@ -0,0 +1,31 @@
+package task
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul task
+var (
+	ErrTaskNotFound       = apperror.NotFound("TASK_NOT_FOUND", "task not found")
+	ErrTaskForbidden      = apperror.Forbidden("TASK_FORBIDDEN", "unauthorized to access this task")
+	ErrInvalidTaskID      = apperror.BadRequest("INVALID_TASK_ID", "Invalid task ID")
+	ErrTaskCreateFailed   = apperror.Internal("TASK_CREATE_FAILED", "failed to create task", nil)
+	ErrTaskFetchFailed    = apperror.Internal("TASK_FETCH_FAILED", "failed to retrieve task", nil)
+	ErrTaskUpdateFailed   = apperror.Internal("TASK_UPDATE_FAILED", "failed to update task", nil)
+	ErrTaskDeleteFailed   = apperror.Internal("TASK_DELETE_FAILED", "failed to delete task", nil)
+	ErrInvalidRevision    = apperror.BadRequest("INVALID_REVISION", "Invalid revision number")
+	ErrRevisionNotFound   = apperror.NotFound("TASK_REVISION_NOT_FOUND", "task revision not found")
+	ErrProjectNotFound    = apperror.NotFound("PROJECT_NOT_FOUND", "project not found")
+	ErrBulkTagRequired    = apperror.BadRequest("BULK_TAG_REQUIRED", "tag is required for add_tag and remove_tag")
+	ErrBulkTooManyTasks   = apperror.BadRequest("BULK_TOO_MANY_TASKS", "filter matches more than 500 tasks")
+	ErrBulkFilterEmpty    = apperror.Validation("BULK_FILTER_EMPTY", "filter must have at least one condition, set all to true to select every task", nil)
+	ErrTaskNotInTrash     = apperror.NotFound("TASK_NOT_IN_TRASH", "task not found in trash")
+	ErrTaskRestoreFailed  = apperror.Internal("TASK_RESTORE_FAILED", "failed to restore task", nil)
+	ErrTaskPurgeFailed    = apperror.Internal("TASK_PURGE_FAILED", "failed to purge tasks", nil)
+	ErrInvalidMove        = apperror.BadRequest("INVALID_MOVE", "task must be moved between two other tasks in list order")
+	ErrAnchorNotInColumn  = apperror.BadRequest("CARD_ANCHOR_NOT_IN_COLUMN", "anchor task is not in the target column")
+	ErrWIPLimitReached    = apperror.Conflict("COLUMN_WIP_LIMIT_REACHED", "column work-in-progress limit reached")
+	ErrDependencyCycle    = apperror.BadRequest("DEPENDENCY_CYCLE", "dependency would create a cycle")
+	ErrDependencyNotFound = apperror.NotFound("DEPENDENCY_NOT_FOUND", "task dependency not found")
+	ErrTaskBlocked        = apperror.Conflict("TASK_BLOCKED", "task has blockers that are not completed")
+	ErrInvalidFieldFilter = apperror.BadRequest("INVALID_CUSTOM_FIELD_FILTER", "custom field filter must be in key:value format")
+	ErrInvalidFilter      = apperror.BadRequest("INVALID_FILTER", "invalid filter")
+)
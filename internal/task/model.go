@@ -2,7 +2,11 @@ package task
 
 import (
 	"rest-api/internal/auth"
+	"rest-api/internal/customfield"
+	"rest-api/pkg/auditlog"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Task struct {
@@ -11,22 +15,188 @@ type Task struct {
 	Title       string    `gorm:"not null" json:"title"`
 	Description string    `json:"description"`
 	IsCompleted bool      `gorm:"default:false" json:"isCompleted"`
+	ProjectID   *uint     `json:"projectId"` // nil jika task tidak ada di project manapun
+	Priority    string    `gorm:"size:10;not null;default:none" json:"priority"`
+	EstimateMinutes *int  `json:"estimateMinutes"` // estimasi waktu pengerjaan, nil jika belum diestimasi
+	Position    string    `gorm:"size:64;not null" json:"position"` // key urutan manual (pkg/rank), kosong untuk task lama
+	ColumnID    *uint     `json:"columnId"` // kolom board, nil jika task tidak ada di board
+	DueAt       *time.Time `json:"dueAt"`   // tenggat task, nil jika tidak ada
+	CompletedAt *time.Time `json:"completedAt"` // diisi saat task ditandai selesai, dipakai untuk auto-archive
+	ArchivedAt  *time.Time `json:"archivedAt"`  // task diarsipkan jika tidak nil
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"` // soft delete, task masuk trash
 	
 	User        auth.User `gorm:"foreignKey:UserID"` // relasi ke user
+	Tags        []Tag     `gorm:"foreignKey:TaskID"` // tag task, disimpan ulang setiap Update
+	BlockedBy   []Dependency `gorm:"foreignKey:TaskID"`      // task yang harus selesai lebih dulu
+	Blocks      []Dependency `gorm:"foreignKey:BlockedByID"` // task yang menunggu task ini
+	Fields      []FieldValue `gorm:"foreignKey:TaskID"`      // nilai custom field, disimpan ulang setiap Update
+}
+
+// Tag adalah label bebas pada task, disimpan lowercase dan unik per task
+type Tag struct {
+	TaskID uint   `gorm:"primaryKey"`
+	Tag    string `gorm:"primaryKey;size:50"`
+}
+
+func (Tag) TableName() string {
+	return "task_tags"
+}
+
+// Dependency menandakan TaskID diblokir oleh BlockedByID
+type Dependency struct {
+	TaskID      uint      `gorm:"primaryKey"`
+	BlockedByID uint      `gorm:"primaryKey"`
+	CreatedAt   time.Time
+}
+
+func (Dependency) TableName() string {
+	return "task_dependencies"
+}
+
+// FieldValue adalah nilai custom field pada task dalam bentuk kanonik (lihat customfield.Value)
+type FieldValue struct {
+	TaskID  uint     `gorm:"primaryKey"`
+	FieldID uint     `gorm:"primaryKey"`
+	Value   string   `gorm:"size:500;not null"`
+	Number  *float64 `gorm:"column:number_value"` // diisi untuk field number agar bisa diurutkan secara numerik
+
+	Field *customfield.Field `gorm:"foreignKey:FieldID"` // definisi field, tidak ikut disimpan
+}
+
+func (FieldValue) TableName() string {
+	return "task_field_values"
+}
+
+// Prioritas task
+const (
+	PriorityNone   = "none"
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+)
+
+// Revision adalah satu perubahan task yang disimpan setiap kali UpdateTask berhasil
+// Revision dinomori per task mulai dari 1, revision 0 adalah state task saat dibuat
+type Revision struct {
+	ID         uint      `gorm:"primaryKey"`
+	TaskID     uint      `gorm:"not null"`
+	UserID     *uint     // user yang melakukan perubahan
+	Revision   int       `gorm:"not null"`
+	Changes    string    `gorm:"type:text;not null"` // JSON auditlog.Changes
+	RevertedTo *int      // diisi jika perubahan berasal dari revert
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (Revision) TableName() string {
+	return "task_revisions"
+}
+
+// Nilai filter archived pada list task
+const (
+	ArchivedExclude = "exclude" // default, hanya task yang tidak diarsipkan
+	ArchivedInclude = "include" // semua task
+	ArchivedOnly    = "only"    // hanya task yang diarsipkan
+)
+
+// Urutan list task
+const (
+	SortCreated  = "created"  // default, task terbaru di atas
+	SortPosition = "position" // urutan manual dari endpoint move
+	SortField    = "field"    // nilai custom field sortField, task tanpa nilai di bawah
+	SortDue      = "due"      // tenggat terdekat di atas, task tanpa tenggat di bawah
+)
+
+// Filter tenggat relatif terhadap waktu sekarang di timezone query (default UTC)
+const (
+	DueToday     = "today"     // tenggat hari ini
+	DueNext7Days = "next7days" // tenggat dari awal hari ini sampai 7 hari ke depan
+	DueOverdue   = "overdue"   // tenggat sudah lewat dan task belum selesai
+	DueNone      = "none"      // task tanpa tenggat
+)
+
+// Arah urutan untuk SortField
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Query DTOs
+type ListQuery struct {
+	Archived  string   `json:"archived" query:"archived" validate:"omitempty,oneof=include only exclude"`
+	ProjectID *uint    `json:"projectId" query:"projectId"`
+	Tag       string   `json:"tag" query:"tag" validate:"omitempty,max=50"`
+	Priority  string   `json:"priority" query:"priority" validate:"omitempty,oneof=none low medium high"`
+	Completed *bool    `json:"completed" query:"completed"`
+	Sort      string   `json:"sort" query:"sort" validate:"omitempty,oneof=created position field due"`
+	SortField string   `json:"sortField" query:"sortField" validate:"required_if=Sort field,omitempty,max=50"`  // key custom field untuk sort=field
+	Order     string   `json:"order" query:"order" validate:"omitempty,oneof=asc desc"`                         // arah sort=field, default asc
+	Fields    []string `json:"fields" query:"field" validate:"max=10,dive,min=3,max=600"`                       // filter custom field key:value, bisa diulang
+	Search    string   `json:"q" query:"q" validate:"omitempty,max=200"`                                        // cari di judul dan deskripsi
+	Due       string   `json:"due" query:"due" validate:"omitempty,oneof=today next7days overdue none"`
+	DueFrom   string   `json:"dueFrom" query:"dueFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DueTo     string   `json:"dueTo" query:"dueTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`     // eksklusif
+	Timezone  string   `json:"tz" query:"tz" validate:"omitempty,timezone"`                                     // timezone untuk due relatif, contoh Asia/Jakarta
+	Filter    string   `json:"filter" query:"filter" validate:"omitempty,max=500"`                              // filter DSL, contoh: status:open AND (tag:bug OR priority>=high)
 }
 
 // Request DTOs
 type CreateRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
+	Title       string   `json:"title" validate:"required,max=255"`
+	Description string   `json:"description"`
+	ProjectID   *uint    `json:"projectId"`
+	Priority    string   `json:"priority" validate:"omitempty,oneof=none low medium high"`
+	Tags        []string `json:"tags" validate:"max=20,dive,min=1,max=50"`
+	EstimateMinutes *int `json:"estimateMinutes" validate:"omitnil,min=1,max=100000"`
+	CustomFields map[string]interface{} `json:"customFields" validate:"max=50"` // key custom field -> nilai
+	DueAt       string   `json:"dueAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 }
 
+// Field pointer bernilai nil jika tidak dikirim, omitnil melewati validasi untuk field tersebut
 type UpdateRequest struct {
-	Title       *string `json:"title"`
+	Title       *string `json:"title" validate:"omitnil,min=1,max=255"`
 	Description *string `json:"description"`
 	IsCompleted *bool   `json:"isCompleted"`
+	ProjectID   *uint   `json:"projectId"` // 0 = keluarkan dari project
+	Priority    *string `json:"priority" validate:"omitnil,oneof=none low medium high"`
+	Tags        *[]string `json:"tags" validate:"omitnil,max=20,dive,min=1,max=50"` // mengganti semua tag
+	EstimateMinutes *int  `json:"estimateMinutes" validate:"omitnil,min=0,max=100000"` // 0 = hapus estimasi
+	CustomFields map[string]interface{} `json:"customFields" validate:"max=50"` // hanya key yang dikirim yang diubah, null = hapus nilai
+	DueAt       *string `json:"dueAt" validate:"omitnil,eq=|datetime=2006-01-02T15:04:05Z07:00"` // string kosong = hapus tenggat
+}
+
+// UnarchiveRequest berisi ID task yang dikeluarkan dari arsip, atau all=true untuk semua task
+type UnarchiveRequest struct {
+	IDs []uint `json:"ids" validate:"required_without=All,max=500"`
+	All bool   `json:"all"`
+}
+
+// MoveRequest memindahkan task tepat setelah afterId dan/atau tepat sebelum beforeId
+// afterId adalah task di atas posisi baru, beforeId task di bawahnya, minimal salah satu wajib diisi
+type MoveRequest struct {
+	AfterID  *uint `json:"afterId" validate:"required_without=BeforeID"`
+	BeforeID *uint `json:"beforeId" validate:"required_without=AfterID"`
+}
+
+// DependencyRequest menambahkan task yang harus selesai sebelum task ini
+type DependencyRequest struct {
+	BlockedByID uint `json:"blockedById" validate:"required"`
+}
+
+// DependencyOrderQuery memilih project untuk urutan topologis
+type DependencyOrderQuery struct {
+	ProjectID uint `json:"projectId" query:"projectId" validate:"required"`
+}
+
+// CardMove memindahkan task ke kolom board, dipakai oleh modul board
+// ColumnID 0 mengeluarkan task dari board, tanpa anchor task ditaruh di bawah card terakhir kolom
+type CardMove struct {
+	ColumnID    uint
+	AfterID     *uint
+	BeforeID    *uint
+	IsCompleted *bool // diisi jika kolom dipetakan ke status task
+	WIPLimit    int   // jumlah card maksimal di kolom, 0 = tanpa batas
 }
 
 // Response DTOs
@@ -35,7 +205,70 @@ type Response struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	IsCompleted bool      `json:"isCompleted"`
+	ProjectID   *uint     `json:"projectId"`
+	Priority    string    `json:"priority"`
+	EstimateMinutes *int  `json:"estimateMinutes"`
+	Tags        []string  `json:"tags"`
+	BlockedBy   []uint    `json:"blockedBy"` // ID task yang memblokir task ini
+	Blocks      []uint    `json:"blocks"`    // ID task yang diblokir task ini
+	CustomFields map[string]interface{} `json:"customFields"` // key custom field -> nilai (number, bool atau string)
+	Position    string    `json:"position"`
+	ColumnID    *uint     `json:"columnId"`
+	DueAt       *time.Time `json:"dueAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"` // hanya diisi untuk task di trash
 	UserID      uint      `json:"userId"`
-}
\ No newline at end of file
+}
+
+// RevisionResponse adalah satu entry di riwayat task
+type RevisionResponse struct {
+	Revision   int              `json:"revision"`
+	UserID     *uint            `json:"userId"`
+	Changes    auditlog.Changes `json:"changes"`
+	RevertedTo *int             `json:"revertedTo,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
+
+// Operasi bulk
+const (
+	BulkComplete    = "complete"
+	BulkReopen      = "reopen"
+	BulkDelete      = "delete"
+	BulkMove        = "move"
+	BulkAddTag      = "add_tag"
+	BulkRemoveTag   = "remove_tag"
+	BulkSetPriority = "set_priority"
+)
+
+// BulkRequest menjalankan satu operasi ke beberapa task, dipilih lewat ids atau filter
+// projectId wajib untuk move (0 = keluarkan dari project), tag untuk add_tag/remove_tag, priority untuk set_priority
+// Filter tanpa kondisi ditolak kecuali all bernilai true, agar operasi ke semua task selalu disengaja
+type BulkRequest struct {
+	IDs       []uint     `json:"ids" validate:"required_without_all=Filter All,max=500"`
+	Filter    *ListQuery `json:"filter"`
+	All       bool       `json:"all"`
+	Operation string     `json:"operation" validate:"required,oneof=complete reopen delete move add_tag remove_tag set_priority"`
+	ProjectID *uint      `json:"projectId" validate:"required_if=Operation move"`
+	Tag       string     `json:"tag" validate:"omitempty,max=50"`
+	Priority  string     `json:"priority" validate:"required_if=Operation set_priority,omitempty,oneof=none low medium high"`
+}
+
+// BulkItemResult adalah hasil operasi bulk untuk satu task
+// Status dan code sama dengan response endpoint task tunggal (contoh: 403 TASK_FORBIDDEN)
+type BulkItemResult struct {
+	ID      uint      `json:"id"`
+	Success bool      `json:"success"`
+	Status  int       `json:"status"`
+	Code    string    `json:"code,omitempty"`
+	Task    *Response `json:"task,omitempty"`
+}
+
+type BulkResponse struct {
+	Operation string           `json:"operation"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
@@ -0,0 +1,278 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/rank"
+)
+
+// MoveTask implements Service.
+// Posisi baru dibuat di antara posisi task tetangga (pkg/rank) sehingga hanya satu baris yang diubah
+// Posisi dibagi ulang untuk semua task user jika key menjadi terlalu panjang
+// Perubahan posisi dicatat di audit log (task.move), bukan revision, karena posisi tidak bisa di-revert
+func (s *service) MoveTask(ctx context.Context, userID, taskID uint, req *MoveRequest) (*Response, error) {
+	if err := s.ensureRanked(ctx, userID); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.rankBetween(ctx, task, req.AfterID, req.BeforeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdatePosition(ctx, taskID, position); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	from := task.Position
+	task.Position = position
+	s.recordMove(ctx, task, from, task.ColumnID, req.AfterID, req.BeforeID)
+
+	return s.rebalanceIfLong(ctx, task)
+}
+
+// MoveCard implements Service.
+// Kolom, posisi dan status selesai task disimpan sekaligus dalam satu Update
+// Perubahan status selesai dicatat sebagai revision dan audit log seperti UpdateTask, perpindahan kolom/posisi sebagai task.move
+// Pengecekan batas WIP dan penyimpanan berjalan dalam satu transaksi agar dua card yang dipindahkan
+// bersamaan tidak bisa sama-sama lolos batas
+func (s *service) MoveCard(ctx context.Context, userID, taskID uint, move *CardMove) (*Response, error) {
+	var res *Response
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		res, err = tx.moveCard(ctx, userID, taskID, move)
+		return err
+	})
+	return res, err
+}
+
+// moveCard memindahkan card, harus dipanggil di dalam transaksi (lihat MoveCard)
+func (s *service) moveCard(ctx context.Context, userID, taskID uint, move *CardMove) (*Response, error) {
+	// Kolom dikunci sebelum query lain agar hitungan card di bawah melihat card yang baru
+	// dipindahkan oleh transaksi sebelumnya (snapshot REPEATABLE READ MySQL dibuat saat read pertama)
+	if move.ColumnID != 0 && move.WIPLimit > 0 {
+		if err := s.repo.LockColumn(ctx, move.ColumnID); err != nil {
+			return nil, ErrTaskUpdateFailed.Wrap(err)
+		}
+	}
+	if err := s.ensureRanked(ctx, userID); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	task, err := s.lockOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := snapshot(task)
+	fromPosition, fromColumn := task.Position, task.ColumnID
+
+	if move.ColumnID == 0 {
+		task.ColumnID = nil
+	} else {
+		// Batas WIP hanya dicek saat card masuk dari kolom lain
+		entering := task.ColumnID == nil || *task.ColumnID != move.ColumnID
+		if move.WIPLimit > 0 && entering {
+			count, err := s.repo.CountInColumn(ctx, move.ColumnID, taskID)
+			if err != nil {
+				return nil, ErrTaskFetchFailed.Wrap(err)
+			}
+			if count >= int64(move.WIPLimit) {
+				return nil, ErrWIPLimitReached
+			}
+		}
+
+		columnID := move.ColumnID
+		position, err := s.rankBetween(ctx, task, move.AfterID, move.BeforeID, &columnID)
+		if err != nil {
+			return nil, err
+		}
+		task.ColumnID = &columnID
+		task.Position = position
+		if move.IsCompleted != nil {
+			task.IsCompleted = *move.IsCompleted
+		}
+	}
+
+	if err := s.save(ctx, userID, task, before, nil); err != nil {
+		return nil, err
+	}
+	s.recordMove(ctx, task, fromPosition, fromColumn, move.AfterID, move.BeforeID)
+
+	return s.rebalanceIfLong(ctx, task)
+}
+
+// recordMove mencatat perubahan posisi dan kolom task di audit log beserta anchor yang diminta
+// Tidak mencatat apa pun jika posisi dan kolom tidak berubah
+func (s *service) recordMove(ctx context.Context, task *Task, fromPosition string, fromColumn *uint, afterID, beforeID *uint) {
+	changes := auditlog.Diff(
+		map[string]interface{}{"position": fromPosition, "columnId": idValue(fromColumn)},
+		map[string]interface{}{"position": task.Position, "columnId": idValue(task.ColumnID)},
+	)
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := auditlog.Target(auditlog.ActionTaskMove, auditlog.TargetTask, task.ID)
+	entry.Changes = changes
+	entry.Metadata = map[string]interface{}{"afterId": idValue(afterID), "beforeId": idValue(beforeID)}
+	s.audit.Record(ctx, entry)
+}
+
+// idValue mengubah ID opsional menjadi nilai audit log, nil tetap nil
+func idValue(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// GetCards implements Service.
+// Card diurutkan berdasarkan posisi, task yang diarsipkan atau di trash tidak ikut
+func (s *service) GetCards(ctx context.Context, userID uint, columnIDs []uint) ([]Response, error) {
+	if len(columnIDs) == 0 {
+		return []Response{}, nil
+	}
+
+	tasks, err := s.repo.FindAllByUserID(ctx, userID, Filter{ColumnIDs: columnIDs, Sort: SortPosition})
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(tasks))
+	for i := range tasks {
+		responses[i] = *toResponse(&tasks[i])
+	}
+
+	return responses, nil
+}
+
+// rankBetween menghitung posisi baru task dari anchor
+// Anchor punya posisi yang sama jika dua task dipindahkan bersamaan, posisi dibagi ulang lalu dihitung sekali lagi
+func (s *service) rankBetween(ctx context.Context, task *Task, afterID, beforeID, columnID *uint) (string, error) {
+	for attempt := 0; ; attempt++ {
+		position, err := s.movePosition(ctx, task, afterID, beforeID, columnID)
+		if !errors.Is(err, rank.ErrInvalidRange) {
+			return position, err
+		}
+		if attempt > 0 {
+			return "", ErrInvalidMove
+		}
+		if err := s.repo.Rebalance(ctx, task.UserID); err != nil {
+			return "", ErrTaskUpdateFailed.Wrap(err)
+		}
+	}
+}
+
+// movePosition menghitung posisi di antara anchor
+// Jika hanya satu anchor yang diisi, batas lainnya adalah posisi tetangga anchor tersebut
+// Jika columnID diisi, anchor harus ada di kolom tersebut, tanpa anchor task ditaruh di bawah card terakhir kolom
+func (s *service) movePosition(ctx context.Context, task *Task, afterID, beforeID, columnID *uint) (string, error) {
+	var lower, upper string
+	if afterID != nil {
+		anchor, err := s.moveAnchor(ctx, task, *afterID, columnID)
+		if err != nil {
+			return "", err
+		}
+		lower = anchor.Position
+	}
+	if beforeID != nil {
+		anchor, err := s.moveAnchor(ctx, task, *beforeID, columnID)
+		if err != nil {
+			return "", err
+		}
+		upper = anchor.Position
+	}
+
+	var err error
+	switch {
+	case afterID != nil && beforeID != nil:
+		if lower > upper {
+			return "", ErrInvalidMove
+		}
+	case afterID != nil:
+		upper, err = s.repo.NeighborPosition(ctx, task.UserID, lower, true, task.ID)
+	case beforeID != nil:
+		lower, err = s.repo.NeighborPosition(ctx, task.UserID, upper, false, task.ID)
+	case columnID != nil:
+		lower, err = s.repo.LastColumnPosition(ctx, *columnID, task.ID)
+		if err == nil && lower == "" {
+			// Kolom masih kosong, posisi task tidak perlu berubah
+			return task.Position, nil
+		}
+		if err == nil {
+			upper, err = s.repo.NeighborPosition(ctx, task.UserID, lower, true, task.ID)
+		}
+	default:
+		return "", ErrInvalidMove
+	}
+	if err != nil {
+		return "", ErrTaskFetchFailed.Wrap(err)
+	}
+
+	return rank.Between(lower, upper)
+}
+
+// moveAnchor mengambil task anchor, anchor harus milik user dan bukan task yang dipindahkan
+func (s *service) moveAnchor(ctx context.Context, task *Task, anchorID uint, columnID *uint) (*Task, error) {
+	if anchorID == task.ID {
+		return nil, ErrInvalidMove
+	}
+	anchor, err := s.findOwned(ctx, task.UserID, anchorID)
+	if err != nil {
+		return nil, err
+	}
+	if columnID != nil && (anchor.ColumnID == nil || *anchor.ColumnID != *columnID) {
+		return nil, ErrAnchorNotInColumn
+	}
+	return anchor, nil
+}
+
+// rebalanceIfLong membagi ulang posisi jika key task melebihi rank.MaxLength lalu mengembalikan task terbaru
+func (s *service) rebalanceIfLong(ctx context.Context, task *Task) (*Response, error) {
+	if len(task.Position) <= rank.MaxLength {
+		return toResponse(task), nil
+	}
+
+	if err := s.repo.Rebalance(ctx, task.UserID); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	task, err := s.findOwned(ctx, task.UserID, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(task), nil
+}
+
+// topPosition mengembalikan posisi di atas semua task user, dipakai untuk task baru
+// Setiap task baru memperpanjang key teratas, jadi posisi dibagi ulang sebelum key melebihi rank.MaxLength
+func (s *service) topPosition(ctx context.Context, userID uint) (string, error) {
+	if err := s.ensureRanked(ctx, userID); err != nil {
+		return "", err
+	}
+	for attempt := 0; ; attempt++ {
+		first, err := s.repo.FirstPosition(ctx, userID)
+		if err != nil {
+			return "", err
+		}
+		position, err := rank.Between("", first)
+		if err != nil || len(position) <= rank.MaxLength || attempt > 0 {
+			return position, err
+		}
+		if err := s.repo.Rebalance(ctx, userID); err != nil {
+			return "", err
+		}
+	}
+}
+
+// ensureRanked memberi posisi ke task user yang dibuat sebelum manual ordering
+// Urutan awalnya sama dengan urutan default (terbaru di atas)
+func (s *service) ensureRanked(ctx context.Context, userID uint) error {
+	unpositioned, err := s.repo.HasUnpositioned(ctx, userID)
+	if err != nil || !unpositioned {
+		return err
+	}
+	return s.repo.Rebalance(ctx, userID)
+}
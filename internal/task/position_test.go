@@ -0,0 +1,175 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"rest-api/internal/search"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/config"
+	"rest-api/pkg/rank"
+)
+
+// Tanpa rebalance, key teratas bertambah satu karakter sekitar setiap 36 task baru dan melewati
+// kolom position VARCHAR(64) sekitar task ke-1153
+func TestCreateTaskKeepsPositionsShort(t *testing.T) {
+	ctx := context.Background()
+	svc, _, userID := newTestService(t)
+
+	const count = 1250
+	for i := range count {
+		if _, err := svc.CreateTask(ctx, userID, &CreateRequest{Title: fmt.Sprintf("task %d", i)}); err != nil {
+			t.Fatalf("CreateTask(%d) error = %v", i, err)
+		}
+	}
+
+	tasks, err := svc.GetTasksByUserID(ctx, userID, &ListQuery{Sort: SortPosition})
+	if err != nil {
+		t.Fatalf("GetTasksByUserID() error = %v", err)
+	}
+	if len(tasks) != count {
+		t.Fatalf("GetTasksByUserID() = %d tasks, want %d", len(tasks), count)
+	}
+	for i, task := range tasks {
+		if len(task.Position) > rank.MaxLength {
+			t.Fatalf("task %q position %q is %d chars, want at most %d", task.Title, task.Position, len(task.Position), rank.MaxLength)
+		}
+		// Task baru selalu paling atas, jadi urutan manual adalah kebalikan urutan pembuatan
+		if want := fmt.Sprintf("task %d", count-1-i); task.Title != want {
+			t.Fatalf("task at index %d = %q, want %q", i, task.Title, want)
+		}
+	}
+}
+
+func TestMoveCardEnforcesWIPLimitConcurrently(t *testing.T) {
+	ctx := context.Background()
+	svc, db, userID := newTestService(t)
+
+	if err := db.Exec("INSERT INTO boards (id, user_id, name) VALUES (1, ?, 'Sprint')", userID).Error; err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if err := db.Exec("INSERT INTO board_columns (id, board_id, name, wip_limit) VALUES (1, 1, 'Doing', 2)").Error; err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+
+	const count = 20
+	ids := make([]uint, count)
+	for i := range ids {
+		res, err := svc.CreateTask(ctx, userID, &CreateRequest{Title: fmt.Sprintf("card %d", i)})
+		if err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+		ids[i] = res.ID
+	}
+
+	// Semua goroutine dimulai bersamaan agar pengecekan batas WIP saling tumpang tindih
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, count)
+	for i, id := range ids {
+		wg.Go(func() {
+			<-start
+			_, errs[i] = svc.MoveCard(ctx, userID, id, &CardMove{ColumnID: 1, WIPLimit: 2})
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	moved := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			moved++
+		case !errors.Is(err, ErrWIPLimitReached):
+			t.Errorf("MoveCard(%d) error = %v, want nil or %v", ids[i], err, ErrWIPLimitReached)
+		}
+	}
+	if moved != 2 {
+		t.Errorf("MoveCard() succeeded %d times, want 2", moved)
+	}
+
+	var inColumn int64
+	if err := db.Model(&Task{}).Where("column_id = ?", 1).Count(&inColumn).Error; err != nil {
+		t.Fatalf("count column: %v", err)
+	}
+	if inColumn != 2 {
+		t.Errorf("tasks in column = %d, want WIP limit 2", inColumn)
+	}
+}
+
+func TestMoveRecordsAudit(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+	audit := &bufferedRecorder{}
+	svc := NewService(repo, &config.Config{}, audit, search.NewIndex(db))
+
+	ids := make([]uint, 3)
+	for i := range ids {
+		res, err := svc.CreateTask(ctx, userID, &CreateRequest{Title: fmt.Sprintf("task %d", i)})
+		if err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+		ids[i] = res.ID
+	}
+	// lastMove mengembalikan event task.move terakhir
+	lastMove := func() auditlog.Entry {
+		t.Helper()
+		for i := len(audit.entries) - 1; i >= 0; i-- {
+			if audit.entries[i].Action == auditlog.ActionTaskMove {
+				return audit.entries[i]
+			}
+		}
+		t.Fatalf("no %s entry in %+v", auditlog.ActionTaskMove, audit.entries)
+		return auditlog.Entry{}
+	}
+
+	// Urutan awal: task 2, task 1, task 0 (task baru di atas)
+	before, err := svc.GetTaskByID(ctx, userID, ids[0])
+	if err != nil {
+		t.Fatalf("GetTaskByID() error = %v", err)
+	}
+	moved, err := svc.MoveTask(ctx, userID, ids[0], &MoveRequest{AfterID: &ids[2]})
+	if err != nil {
+		t.Fatalf("MoveTask() error = %v", err)
+	}
+	entry := lastMove()
+	if entry.TargetID != fmt.Sprint(ids[0]) {
+		t.Errorf("MoveTask() audit target = %s, want %d", entry.TargetID, ids[0])
+	}
+	if got, want := entry.Changes["position"], (auditlog.Change{From: before.Position, To: moved.Position}); got != want {
+		t.Errorf("MoveTask() audit position = %+v, want %+v", got, want)
+	}
+	if _, ok := entry.Changes["columnId"]; ok {
+		t.Errorf("MoveTask() audit changes = %+v, want no column change", entry.Changes)
+	}
+	if entry.Metadata["afterId"] != ids[2] || entry.Metadata["beforeId"] != nil {
+		t.Errorf("MoveTask() audit metadata = %+v, want afterId %d", entry.Metadata, ids[2])
+	}
+
+	if err := db.Exec("INSERT INTO boards (id, user_id, name) VALUES (1, ?, 'Sprint')", userID).Error; err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if err := db.Exec("INSERT INTO board_columns (id, board_id, name) VALUES (1, 1, 'Doing')").Error; err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	if _, err := svc.MoveCard(ctx, userID, ids[1], &CardMove{ColumnID: 1}); err != nil {
+		t.Fatalf("MoveCard() error = %v", err)
+	}
+	entry = lastMove()
+	if got, want := entry.Changes["columnId"], (auditlog.Change{From: nil, To: uint(1)}); entry.TargetID != fmt.Sprint(ids[1]) || got != want {
+		t.Errorf("MoveCard() audit = %s %+v, want column change %+v", entry.TargetID, entry.Changes, want)
+	}
+
+	// Memindahkan ke posisi yang sama tidak mencatat apa pun
+	count := len(audit.entries)
+	if _, err := svc.MoveCard(ctx, userID, ids[1], &CardMove{ColumnID: 1}); err != nil {
+		t.Fatalf("MoveCard() error = %v", err)
+	}
+	if len(audit.entries) != count {
+		t.Errorf("MoveCard() to the same column recorded %+v", audit.entries[count:])
+	}
+}
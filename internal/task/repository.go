@@ -1,15 +1,119 @@
 package task
 
 import (
+	"context"
+	"fmt"
+	"rest-api/internal/auth"
+	"rest-api/internal/customfield"
+	"rest-api/pkg/rank"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Filter adalah kriteria list task milik user
+// Field kosong/nil diabaikan
+type Filter struct {
+	Archived  string // ArchivedExclude (default), ArchivedInclude atau ArchivedOnly
+	ProjectID *uint
+	Tag       string
+	Priority  string
+	Completed *bool
+	Sort      string // SortCreated (default) atau SortPosition
+	ColumnIDs []uint // kolom board, nil = semua task
+	Fields    []FieldFilter // semua custom field harus bernilai sama
+	FieldSort *FieldSort    // dipakai jika Sort bernilai SortField
+	Search    string        // cari di judul dan deskripsi (case-insensitive)
+	DueFrom   *time.Time    // tenggat >= DueFrom
+	DueTo     *time.Time    // tenggat < DueTo
+	NoDue     bool          // hanya task tanpa tenggat
+	Expr      *FilterExpr   // filter DSL yang sudah dikompilasi, digabung (AND) dengan kriteria lain
+	Limit     int           // jumlah maksimal task, 0 = tanpa batas
+}
+
+// Operator FilterExpr selain operator perbandingan (=, !=, <, <=, >, >=)
+const (
+	FilterAnd         = "and"
+	FilterOr          = "or"
+	FilterNot         = "not"
+	FilterIn          = "in"           // Value berupa slice
+	FilterContains    = "contains"     // Value string, case-insensitive
+	FilterNotContains = "not_contains" // Value string, case-insensitive
 )
 
+// FilterExpr adalah ekspresi filter DSL yang sudah divalidasi dan nilainya sudah di-resolve
+// FilterAnd/FilterOr/FilterNot memakai Args, operator lain membandingkan Field dengan Value
+// Value nil dengan operator = atau != berarti IS NULL atau IS NOT NULL
+type FilterExpr struct {
+	Op    string
+	Args  []FilterExpr
+	Field string // key filterColumns atau "tag"
+	Value interface{}
+}
+
+// filterColumns memetakan field FilterExpr ke kolom tasks, nama kolom tidak pernah diambil dari input user
+var filterColumns = map[string]string{
+	"status":   "is_completed",
+	"priority": "priority",
+	"project":  "project_id",
+	"due":      "due_at",
+	"created":  "created_at",
+	"title":    "title",
+}
+
+// nullableFilterColumns adalah kolom filterColumns yang boleh NULL
+// Perbandingan dengan NULL menghasilkan NULL (bukan true/false), jadi kolom ini perlu IS NULL eksplisit pada != dan NOT
+var nullableFilterColumns = map[string]bool{
+	"project_id": true,
+	"due_at":     true,
+}
+
+// FieldFilter mencocokkan nilai kanonik custom field
+type FieldFilter struct {
+	FieldID uint
+	Value   string
+}
+
+// FieldSort mengurutkan task berdasarkan nilai custom field, task tanpa nilai ditaruh paling bawah
+type FieldSort struct {
+	FieldID uint
+	Numeric bool // urutkan berdasarkan number_value, bukan value
+	Desc    bool
+}
+
 type Repository interface {
-	Create(task *Task) error
-	Update(task *Task) error
-	FindByID(id uint) (*Task, error)
-	Delete(task *Task) error
-	FindAllByUserID(userID uint) ([]Task, error)
+	Create(ctx context.Context, task *Task) error
+	Update(ctx context.Context, task *Task, revision *Revision) error
+	FindByID(ctx context.Context, id uint) (*Task, error)
+	Delete(ctx context.Context, task *Task) error
+	FindAllByUserID(ctx context.Context, userID uint, filter Filter) ([]Task, error)
+	FindRevisions(ctx context.Context, taskID uint) ([]Revision, error)
+	FindDeletedByID(ctx context.Context, id uint) (*Task, error)
+	FindDeletedByUserID(ctx context.Context, userID uint) ([]Task, error)
+	Restore(ctx context.Context, task *Task) error
+	DeletePermanently(ctx context.Context, task *Task) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	Unarchive(ctx context.Context, userID uint, ids []uint) (int64, error)
+	ArchiveCompleted(ctx context.Context, now time.Time) (int64, error)
+	ProjectExists(ctx context.Context, userID, projectID uint) (bool, error)
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
+	FirstPosition(ctx context.Context, userID uint) (string, error)
+	NeighborPosition(ctx context.Context, userID uint, position string, after bool, excludeID uint) (string, error)
+	HasUnpositioned(ctx context.Context, userID uint) (bool, error)
+	UpdatePosition(ctx context.Context, taskID uint, position string) error
+	Rebalance(ctx context.Context, userID uint) error
+	LastColumnPosition(ctx context.Context, columnID, excludeID uint) (string, error)
+	CountInColumn(ctx context.Context, columnID, excludeID uint) (int64, error)
+	LockColumn(ctx context.Context, columnID uint) error
+	LockUser(ctx context.Context, userID uint) error
+	LockTask(ctx context.Context, taskID uint) error
+	AddDependency(ctx context.Context, dependency *Dependency) error
+	RemoveDependency(ctx context.Context, taskID, blockedByID uint) (int64, error)
+	FindDependencies(ctx context.Context, userID uint) ([]Dependency, error)
+	CountOpenBlockers(ctx context.Context, taskID uint) (int64, error)
+	FindFields(ctx context.Context, userID uint) ([]customfield.Field, error)
 }
 
 type repository struct {
@@ -17,21 +121,144 @@ type repository struct {
 }
 
 // Create implements Repository.
-func (r *repository) Create(task *Task) error {
-	return r.db.Create(task).Error
+// Tag dan nilai custom field task ikut disimpan lewat asosiasi
+func (r *repository) Create(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Omit("Fields.Field").Create(task).Error
 }
 
 // Delete implements Repository.
-func (r *repository) Delete(task *Task) error {
-	return r.db.Delete(task).Error
+// Task di-soft delete (deleted_at diisi), gunakan DeletePermanently untuk menghapus baris
+func (r *repository) Delete(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Delete(task).Error
+}
+
+// DeletePermanently implements Repository.
+// Revision task ikut terhapus lewat ON DELETE CASCADE
+func (r *repository) DeletePermanently(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(task).Error
+}
+
+// FindDeletedByID implements Repository.
+func (r *repository) FindDeletedByID(ctx context.Context, id uint) (*Task, error) {
+	var task Task
+	if err := r.db.WithContext(ctx).Unscoped().
+		Scopes(preloadRelations).
+		Where("deleted_at IS NOT NULL").
+		First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// FindDeletedByUserID implements Repository.
+func (r *repository) FindDeletedByUserID(ctx context.Context, userID uint) ([]Task, error) {
+	var tasks []Task
+	if err := r.db.WithContext(ctx).Unscoped().
+		Scopes(preloadRelations).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at desc").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Restore implements Repository.
+func (r *repository) Restore(ctx context.Context, task *Task) error {
+	if err := r.db.WithContext(ctx).Unscoped().
+		Model(task).
+		Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	task.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// PurgeDeletedBefore implements Repository.
+func (r *repository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&Task{})
+	return result.RowsAffected, result.Error
 }
 
 // FindAllByUserID implements Repository.
-func (r *repository) FindAllByUserID(userID uint) ([]Task, error) {
+func (r *repository) FindAllByUserID(ctx context.Context, userID uint, filter Filter) ([]Task, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	switch filter.Archived {
+	case ArchivedInclude:
+	case ArchivedOnly:
+		query = query.Where("archived_at IS NOT NULL")
+	default:
+		query = query.Where("archived_at IS NULL")
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.Tag != "" {
+		query = query.Where("id IN (?)", r.db.WithContext(ctx).Model(&Tag{}).Select("task_id").Where("tag = ?", filter.Tag))
+	}
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+	if filter.Completed != nil {
+		query = query.Where("is_completed = ?", *filter.Completed)
+	}
+	if filter.ColumnIDs != nil {
+		query = query.Where("column_id IN ?", filter.ColumnIDs)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Search)) + "%"
+		query = query.Where("(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!')", pattern, pattern)
+	}
+	if filter.NoDue {
+		query = query.Where("due_at IS NULL")
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("due_at >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("due_at < ?", *filter.DueTo)
+	}
+	for _, field := range filter.Fields {
+		query = query.Where("id IN (?)", r.db.WithContext(ctx).Model(&FieldValue{}).Select("task_id").Where("field_id = ? AND value = ?", field.FieldID, field.Value))
+	}
+	if filter.Expr != nil {
+		condition, vars, err := r.filterSQL(ctx, *filter.Expr)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, vars...)
+	}
+
+	if filter.Sort == SortPosition {
+		query = query.Order("position asc").Order("id asc")
+	} else if filter.Sort == SortDue {
+		query = query.Order("CASE WHEN due_at IS NULL THEN 1 ELSE 0 END").Order("due_at asc").Order("created_at desc")
+	} else if filter.Sort == SortField && filter.FieldSort != nil {
+		column, direction := "value", "ASC"
+		if filter.FieldSort.Numeric {
+			column = "number_value"
+		}
+		if filter.FieldSort.Desc {
+			direction = "DESC"
+		}
+		// Satu ekspresi ORDER BY karena GORM membuang Expression saat beberapa Order digabung
+		value := r.db.WithContext(ctx).Model(&FieldValue{}).Select(column).Where("task_id = tasks.id AND field_id = ?", filter.FieldSort.FieldID)
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "CASE WHEN (?) IS NULL THEN 1 ELSE 0 END, (?) " + direction + ", created_at desc",
+			Vars: []interface{}{value, value},
+		}})
+	} else {
+		query = query.Order("created_at desc")
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
 	var tasks []Task
-	if err := r.db.
-		Where("user_id = ?", userID).
-		Order("created_at desc").
+	if err := query.
+		Scopes(preloadRelations).
 		Find(&tasks).Error; err != nil {
 		return nil, err
 	}
@@ -39,17 +266,426 @@ func (r *repository) FindAllByUserID(userID uint) ([]Task, error) {
 }
 
 // FindByID implements Repository.
-func (r *repository) FindByID(id uint) (*Task, error) {
+func (r *repository) FindByID(ctx context.Context, id uint) (*Task, error) {
 	var task Task
-	if err := r.db.First(&task, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(preloadRelations).First(&task, id).Error; err != nil {
 		return nil, err
 	}
 	return &task, nil
 }
 
+// FindRevisions implements Repository.
+// Revision diurutkan dari yang terlama
+func (r *repository) FindRevisions(ctx context.Context, taskID uint) ([]Revision, error) {
+	var revisions []Revision
+	if err := r.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("revision asc").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Unarchive implements Repository.
+// ids kosong berarti semua task user yang diarsipkan, ID milik user lain diabaikan
+func (r *repository) Unarchive(ctx context.Context, userID uint, ids []uint) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&Task{}).
+		Where("user_id = ? AND archived_at IS NOT NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("archived_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// ArchiveCompleted implements Repository.
+// Mengarsipkan task yang selesai lebih dari users.auto_archive_days hari sebelum now
+// Dikelompokkan per nilai auto_archive_days agar perhitungan tanggal tetap portable antar database
+func (r *repository) ArchiveCompleted(ctx context.Context, now time.Time) (int64, error) {
+	db := r.db.WithContext(ctx)
+
+	var days []int
+	if err := db.Model(&auth.User{}).
+		Where("auto_archive_days > 0").
+		Distinct().
+		Pluck("auto_archive_days", &days).Error; err != nil {
+		return 0, err
+	}
+
+	var archived int64
+	for _, d := range days {
+		users := db.Model(&auth.User{}).Select("id").Where("auto_archive_days = ?", d)
+		result := db.Model(&Task{}).
+			Where("archived_at IS NULL AND is_completed = ? AND completed_at < ?", true, now.AddDate(0, 0, -d)).
+			Where("user_id IN (?)", users).
+			Update("archived_at", now)
+		if result.Error != nil {
+			return archived, result.Error
+		}
+		archived += result.RowsAffected
+	}
+	return archived, nil
+}
+
 // Update implements Repository.
-func (r *repository) Update(task *Task) error {
-	return r.db.Save(task).Error
+// Jika revision tidak nil, revision disimpan dalam transaksi yang sama dengan nomor revision berikutnya
+func (r *repository) Update(ctx context.Context, task *Task, revision *Revision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(task).Error; err != nil {
+			return err
+		}
+		if err := replaceTags(tx, task); err != nil {
+			return err
+		}
+		if err := replaceFieldValues(tx, task); err != nil {
+			return err
+		}
+		if revision == nil {
+			return nil
+		}
+
+		var latest int
+		if err := tx.Model(&Revision{}).
+			Where("task_id = ?", task.ID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		revision.TaskID = task.ID
+		revision.Revision = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+// ProjectExists implements Repository.
+func (r *repository) ProjectExists(ctx context.Context, userID, projectID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Table("projects").
+		Where("id = ? AND user_id = ?", projectID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Transaction implements Repository.
+// Repository yang diberikan ke fn memakai transaksi yang sama, transaksi di-rollback jika fn mengembalikan error
+func (r *repository) Transaction(ctx context.Context, fn func(repo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
+
+// FirstPosition implements Repository.
+// Mengembalikan string kosong jika user belum punya task dengan posisi
+// Query posisi memakai Unscoped agar task di trash tetap punya tempat saat di-restore
+func (r *repository) FirstPosition(ctx context.Context, userID uint) (string, error) {
+	var positions []string
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Task{}).
+		Where("user_id = ? AND position <> ''", userID).
+		Order("position asc").
+		Limit(1).
+		Pluck("position", &positions).Error; err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[0], nil
+}
+
+// NeighborPosition implements Repository.
+// Mengembalikan posisi terdekat setelah (after) atau sebelum position, string kosong jika tidak ada
+func (r *repository) NeighborPosition(ctx context.Context, userID uint, position string, after bool, excludeID uint) (string, error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&Task{}).
+		Where("user_id = ? AND id <> ? AND position <> ''", userID, excludeID)
+	if after {
+		query = query.Where("position > ?", position).Order("position asc")
+	} else {
+		query = query.Where("position < ?", position).Order("position desc")
+	}
+
+	var positions []string
+	if err := query.Limit(1).Pluck("position", &positions).Error; err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[0], nil
+}
+
+// HasUnpositioned implements Repository.
+func (r *repository) HasUnpositioned(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Task{}).
+		Where("user_id = ? AND position = ''", userID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdatePosition implements Repository.
+// Hanya kolom position yang diubah, updated_at tidak ikut berubah
+func (r *repository) UpdatePosition(ctx context.Context, taskID uint, position string) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&Task{}).
+		Where("id = ?", taskID).
+		UpdateColumn("position", position).Error
+}
+
+// Rebalance implements Repository.
+// Membagi ulang posisi semua task user dengan jarak yang sama tanpa mengubah urutannya
+// Task tanpa posisi (dibuat sebelum manual ordering) ditaruh di bawah, diurutkan dari yang terbaru
+func (r *repository) Rebalance(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Unscoped().Model(&Task{}).
+			Where("user_id = ?", userID).
+			Order("CASE WHEN position = '' THEN 1 ELSE 0 END").
+			Order("position asc").
+			Order("created_at desc").
+			Order("id desc").
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		for i, position := range rank.Spread(len(ids)) {
+			if err := tx.Unscoped().Model(&Task{}).
+				Where("id = ?", ids[i]).
+				UpdateColumn("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LastColumnPosition implements Repository.
+// Mengembalikan posisi card terakhir di kolom, string kosong jika kolom masih kosong
+func (r *repository) LastColumnPosition(ctx context.Context, columnID, excludeID uint) (string, error) {
+	var positions []string
+	if err := r.db.WithContext(ctx).Model(&Task{}).
+		Where("column_id = ? AND id <> ? AND archived_at IS NULL", columnID, excludeID).
+		Order("position desc").
+		Limit(1).
+		Pluck("position", &positions).Error; err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[0], nil
+}
+
+// CountInColumn implements Repository.
+// Task yang diarsipkan atau di trash tidak dihitung
+func (r *repository) CountInColumn(ctx context.Context, columnID, excludeID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Task{}).
+		Where("column_id = ? AND id <> ? AND archived_at IS NULL", columnID, excludeID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// LockColumn implements Repository.
+// Mengunci baris kolom board (SELECT ... FOR UPDATE) sampai transaksi selesai
+// SQLite tidak mendukung row lock, tetapi transaksinya sudah berurutan karena hanya memakai satu koneksi
+func (r *repository) LockColumn(ctx context.Context, columnID uint) error {
+	var ids []uint
+	return r.db.WithContext(ctx).
+		Table("board_columns").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", columnID).
+		Pluck("id", &ids).Error
+}
+
+// LockUser implements Repository.
+// Mengunci baris user (SELECT ... FOR UPDATE) sampai transaksi selesai, dipakai untuk perubahan yang
+// harus dicek terhadap seluruh data user (contoh: siklus dependency)
+func (r *repository) LockUser(ctx context.Context, userID uint) error {
+	var ids []uint
+	return r.db.WithContext(ctx).
+		Model(&auth.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", userID).
+		Pluck("id", &ids).Error
+}
+
+// LockTask implements Repository.
+// Mengunci baris task (SELECT ... FOR UPDATE) sampai transaksi selesai, dipanggil sebelum task dibaca
+// agar perubahan yang disimpan tidak menimpa perubahan transaksi lain
+func (r *repository) LockTask(ctx context.Context, taskID uint) error {
+	var ids []uint
+	return r.db.WithContext(ctx).
+		Model(&Task{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", taskID).
+		Pluck("id", &ids).Error
+}
+
+// AddDependency implements Repository.
+func (r *repository) AddDependency(ctx context.Context, dependency *Dependency) error {
+	return r.db.WithContext(ctx).Create(dependency).Error
+}
+
+// RemoveDependency implements Repository.
+func (r *repository) RemoveDependency(ctx context.Context, taskID, blockedByID uint) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).
+		Delete(&Dependency{})
+	return result.RowsAffected, result.Error
+}
+
+// FindDependencies implements Repository.
+// Mengembalikan semua dependency antar task milik user, termasuk task di trash
+func (r *repository) FindDependencies(ctx context.Context, userID uint) ([]Dependency, error) {
+	var dependencies []Dependency
+	tasks := r.db.WithContext(ctx).Unscoped().Model(&Task{}).Select("id").Where("user_id = ?", userID)
+	if err := r.db.WithContext(ctx).
+		Where("task_id IN (?)", tasks).
+		Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// CountOpenBlockers implements Repository.
+// Blocker yang ada di trash tidak dihitung
+func (r *repository) CountOpenBlockers(ctx context.Context, taskID uint) (int64, error) {
+	var count int64
+	blockers := r.db.WithContext(ctx).Model(&Dependency{}).Select("blocked_by_id").Where("task_id = ?", taskID)
+	if err := r.db.WithContext(ctx).Model(&Task{}).
+		Where("id IN (?) AND is_completed = ?", blockers, false).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindFields implements Repository.
+// Mengembalikan semua definisi custom field milik user
+func (r *repository) FindFields(ctx context.Context, userID uint) ([]customfield.Field, error) {
+	var fields []customfield.Field
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// preloadRelations memuat tag, dependency dan nilai custom field task beserta definisinya
+func preloadRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags").Preload("BlockedBy").Preload("Blocks").Preload("Fields.Field")
+}
+
+// filterSQL menerjemahkan FilterExpr menjadi kondisi SQL, semua nilai dikirim sebagai parameter query
+func (r *repository) filterSQL(ctx context.Context, expr FilterExpr) (string, []interface{}, error) {
+	switch expr.Op {
+	case FilterAnd, FilterOr:
+		parts := make([]string, len(expr.Args))
+		var vars []interface{}
+		for i, arg := range expr.Args {
+			condition, argVars, err := r.filterSQL(ctx, arg)
+			if err != nil {
+				return "", nil, err
+			}
+			parts[i] = "(" + condition + ")"
+			vars = append(vars, argVars...)
+		}
+		return strings.Join(parts, " "+strings.ToUpper(expr.Op)+" "), vars, nil
+	case FilterNot:
+		if len(expr.Args) != 1 {
+			return "", nil, fmt.Errorf("task filter: not expects one argument, got %d", len(expr.Args))
+		}
+		// COALESCE mengubah NULL menjadi FALSE agar task tanpa tenggat/project ikut cocok, contoh: NOT due<today
+		condition, vars, err := r.filterSQL(ctx, expr.Args[0])
+		return "NOT (COALESCE((" + condition + "), FALSE))", vars, err
+	}
+
+	if expr.Field == "tag" {
+		tagged := r.db.WithContext(ctx).Model(&Tag{}).Select("task_id").Where("tag = ?", expr.Value)
+		switch expr.Op {
+		case "=":
+			return "id IN (?)", []interface{}{tagged}, nil
+		case "!=":
+			return "id NOT IN (?)", []interface{}{tagged}, nil
+		}
+		return "", nil, fmt.Errorf("task filter: unsupported operator %q for tag", expr.Op)
+	}
+
+	column, ok := filterColumns[expr.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("task filter: unsupported field %q", expr.Field)
+	}
+	switch expr.Op {
+	case "=", "!=":
+		if expr.Value == nil {
+			if expr.Op == "=" {
+				return column + " IS NULL", nil, nil
+			}
+			return column + " IS NOT NULL", nil, nil
+		}
+		if expr.Op == "!=" {
+			if nullableFilterColumns[column] {
+				return "(" + column + " <> ? OR " + column + " IS NULL)", []interface{}{expr.Value}, nil
+			}
+			return column + " <> ?", []interface{}{expr.Value}, nil
+		}
+		return column + " = ?", []interface{}{expr.Value}, nil
+	case "<", "<=", ">", ">=":
+		return column + " " + expr.Op + " ?", []interface{}{expr.Value}, nil
+	case FilterIn:
+		return column + " IN ?", []interface{}{expr.Value}, nil
+	case FilterContains, FilterNotContains:
+		value, _ := expr.Value.(string)
+		pattern := "%" + escapeLike(strings.ToLower(value)) + "%"
+		if expr.Op == FilterNotContains {
+			return "LOWER(" + column + ") NOT LIKE ? ESCAPE '!'", []interface{}{pattern}, nil
+		}
+		return "LOWER(" + column + ") LIKE ? ESCAPE '!'", []interface{}{pattern}, nil
+	}
+	return "", nil, fmt.Errorf("task filter: unsupported operator %q for %s", expr.Op, expr.Field)
+}
+
+// escapeLike meng-escape karakter wildcard LIKE dengan '!' (portable di semua dialect)
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
+// replaceTags mengganti semua tag task dengan task.Tags
+func replaceTags(tx *gorm.DB, task *Task) error {
+	if err := tx.Where("task_id = ?", task.ID).Delete(&Tag{}).Error; err != nil {
+		return err
+	}
+	if len(task.Tags) == 0 {
+		return nil
+	}
+	for i := range task.Tags {
+		task.Tags[i].TaskID = task.ID
+	}
+	return tx.Create(&task.Tags).Error
+}
+
+// replaceFieldValues mengganti semua nilai custom field task dengan task.Fields
+func replaceFieldValues(tx *gorm.DB, task *Task) error {
+	if err := tx.Where("task_id = ?", task.ID).Delete(&FieldValue{}).Error; err != nil {
+		return err
+	}
+	if len(task.Fields) == 0 {
+		return nil
+	}
+	for i := range task.Fields {
+		task.Fields[i].TaskID = task.ID
+	}
+	return tx.Omit("Field").Create(&task.Fields).Error
 }
 
 func NewRepository(db *gorm.DB) Repository {
@@ -0,0 +1,276 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/database/dbtest"
+
+	"gorm.io/gorm"
+)
+
+// Test di file ini memakai SQLite in-memory secara default, set TEST_DB_DRIVER untuk menjalankannya
+// di MySQL atau PostgreSQL (lihat package dbtest)
+
+func newTestRepository(t *testing.T) (*gorm.DB, Repository) {
+	t.Helper()
+	db := dbtest.Open(t)
+	return db, NewRepository(db)
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, name string) uint {
+	t.Helper()
+	user := &auth.User{Username: name, Email: name + "@mail.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user.ID
+}
+
+// testCreatedAt membuat created_at yang selalu naik agar urutan default (terbaru di atas) tidak bergantung
+// pada presisi timestamp database
+var testCreatedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func createTestTask(t *testing.T, repo Repository, task *Task) *Task {
+	t.Helper()
+	if task.Priority == "" {
+		task.Priority = PriorityNone
+	}
+	if task.CreatedAt.IsZero() {
+		testCreatedAt = testCreatedAt.Add(time.Second)
+		task.CreatedAt = testCreatedAt
+	}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("create task %q: %v", task.Title, err)
+	}
+	return task
+}
+
+func taskIDs(tasks []Task) []uint {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func uintPtr(v uint) *uint           { return &v }
+func boolPtr(v bool) *bool           { return &v }
+func strPtr(v string) *string        { return &v }
+func timePtr(v time.Time) *time.Time { return &v }
+
+func TestRepositoryFindAllByUserIDFilters(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+	otherID := createTestUser(t, db, "ani")
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	report := createTestTask(t, repo, &Task{UserID: userID, Title: "Laporan 50% selesai", ProjectID: uintPtr(1), Priority: PriorityHigh,
+		DueAt: timePtr(day.Add(9 * time.Hour)), Tags: []Tag{{Tag: "kantor"}, {Tag: "bug"}}})
+	meeting := createTestTask(t, repo, &Task{UserID: userID, Title: "Rapat", Description: "bahas LAPORAN", IsCompleted: true,
+		DueAt: timePtr(day.AddDate(0, 0, 1)), Tags: []Tag{{Tag: "kantor"}}})
+	shopping := createTestTask(t, repo, &Task{UserID: userID, Title: "Belanja 50 persen", Priority: PriorityLow})
+	archived := createTestTask(t, repo, &Task{UserID: userID, Title: "Arsip", ArchivedAt: timePtr(day)})
+	createTestTask(t, repo, &Task{UserID: otherID, Title: "Laporan user lain", Tags: []Tag{{Tag: "kantor"}}})
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []uint
+	}{
+		{"default excludes archived", Filter{}, []uint{shopping.ID, meeting.ID, report.ID}},
+		{"archived only", Filter{Archived: ArchivedOnly}, []uint{archived.ID}},
+		{"archived include", Filter{Archived: ArchivedInclude}, []uint{archived.ID, shopping.ID, meeting.ID, report.ID}},
+		{"project", Filter{ProjectID: uintPtr(1)}, []uint{report.ID}},
+		{"tag", Filter{Tag: "kantor"}, []uint{meeting.ID, report.ID}},
+		{"priority", Filter{Priority: PriorityLow}, []uint{shopping.ID}},
+		{"completed", Filter{Completed: boolPtr(true)}, []uint{meeting.ID}},
+		{"search is case-insensitive across title and description", Filter{Search: "laporan"}, []uint{meeting.ID, report.ID}},
+		{"search escapes LIKE wildcards", Filter{Search: "50%"}, []uint{report.ID}},
+		{"due range is half-open", Filter{DueFrom: timePtr(day), DueTo: timePtr(day.AddDate(0, 0, 1))}, []uint{report.ID}},
+		{"no due", Filter{NoDue: true}, []uint{shopping.ID}},
+		{"sort due puts tasks without due date last", Filter{Sort: SortDue}, []uint{report.ID, meeting.ID, shopping.ID}},
+		{"limit", Filter{Limit: 2}, []uint{shopping.ID, meeting.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := repo.FindAllByUserID(ctx, userID, tt.filter)
+			if err != nil {
+				t.Fatalf("FindAllByUserID() error = %v", err)
+			}
+			if got := taskIDs(tasks); !slices.Equal(got, tt.want) {
+				t.Fatalf("FindAllByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryFindAllByUserIDPreloadsTags(t *testing.T) {
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+	createTestTask(t, repo, &Task{UserID: userID, Title: "Tagged", Tags: []Tag{{Tag: "bug"}, {Tag: "kantor"}}})
+
+	tasks, err := repo.FindAllByUserID(context.Background(), userID, Filter{})
+	if err != nil {
+		t.Fatalf("FindAllByUserID() error = %v", err)
+	}
+	if len(tasks) != 1 || len(tasks[0].Tags) != 2 {
+		t.Fatalf("FindAllByUserID() tags = %+v, want 2 tags", tasks)
+	}
+}
+
+func TestRepositoryPositions(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+
+	for i, position := range []string{"m", "c", "t"} {
+		createTestTask(t, repo, &Task{UserID: userID, Title: fmt.Sprintf("task %d", i), Position: position})
+	}
+	legacy := createTestTask(t, repo, &Task{UserID: userID, Title: "legacy"})
+
+	first, err := repo.FirstPosition(ctx, userID)
+	if err != nil || first != "c" {
+		t.Fatalf("FirstPosition() = %q, %v, want c", first, err)
+	}
+	next, err := repo.NeighborPosition(ctx, userID, "c", true, 0)
+	if err != nil || next != "m" {
+		t.Fatalf("NeighborPosition(after c) = %q, %v, want m", next, err)
+	}
+	prev, err := repo.NeighborPosition(ctx, userID, "c", false, 0)
+	if err != nil || prev != "" {
+		t.Fatalf("NeighborPosition(before c) = %q, %v, want empty", prev, err)
+	}
+	if has, err := repo.HasUnpositioned(ctx, userID); err != nil || !has {
+		t.Fatalf("HasUnpositioned() = %v, %v, want true", has, err)
+	}
+
+	if err := repo.Rebalance(ctx, userID); err != nil {
+		t.Fatalf("Rebalance() error = %v", err)
+	}
+	tasks, err := repo.FindAllByUserID(ctx, userID, Filter{Sort: SortPosition})
+	if err != nil {
+		t.Fatalf("FindAllByUserID() error = %v", err)
+	}
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	if want := []string{"task 1", "task 0", "task 2", "legacy"}; !slices.Equal(titles, want) {
+		t.Fatalf("order after Rebalance() = %v, want %v", titles, want)
+	}
+	if has, _ := repo.HasUnpositioned(ctx, userID); has {
+		t.Fatalf("HasUnpositioned() after Rebalance() = true, legacy task %d not positioned", legacy.ID)
+	}
+}
+
+func TestRepositoryDependencies(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+	otherID := createTestUser(t, db, "ani")
+
+	task := createTestTask(t, repo, &Task{UserID: userID, Title: "blocked"})
+	open := createTestTask(t, repo, &Task{UserID: userID, Title: "open blocker"})
+	done := createTestTask(t, repo, &Task{UserID: userID, Title: "done blocker", IsCompleted: true})
+	trashed := createTestTask(t, repo, &Task{UserID: userID, Title: "trashed blocker"})
+	other := createTestTask(t, repo, &Task{UserID: otherID, Title: "other"})
+
+	for _, blocker := range []*Task{open, done, trashed} {
+		if err := repo.AddDependency(ctx, &Dependency{TaskID: task.ID, BlockedByID: blocker.ID}); err != nil {
+			t.Fatalf("AddDependency() error = %v", err)
+		}
+	}
+	if err := repo.AddDependency(ctx, &Dependency{TaskID: other.ID, BlockedByID: other.ID}); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	if err := repo.Delete(ctx, trashed); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	count, err := repo.CountOpenBlockers(ctx, task.ID)
+	if err != nil || count != 1 {
+		t.Fatalf("CountOpenBlockers() = %d, %v, want 1", count, err)
+	}
+
+	dependencies, err := repo.FindDependencies(ctx, userID)
+	if err != nil || len(dependencies) != 3 {
+		t.Fatalf("FindDependencies() = %d dependencies, %v, want 3", len(dependencies), err)
+	}
+
+	removed, err := repo.RemoveDependency(ctx, task.ID, open.ID)
+	if err != nil || removed != 1 {
+		t.Fatalf("RemoveDependency() = %d, %v, want 1", removed, err)
+	}
+	if count, _ := repo.CountOpenBlockers(ctx, task.ID); count != 0 {
+		t.Fatalf("CountOpenBlockers() after remove = %d, want 0", count)
+	}
+}
+
+func TestRepositoryTransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+
+	rollback := fmt.Errorf("rollback")
+	err := repo.Transaction(ctx, func(tx Repository) error {
+		createTestTask(t, tx, &Task{UserID: userID, Title: "rolled back"})
+		return rollback
+	})
+	if err != rollback {
+		t.Fatalf("Transaction() error = %v, want %v", err, rollback)
+	}
+
+	tasks, err := repo.FindAllByUserID(ctx, userID, Filter{})
+	if err != nil || len(tasks) != 0 {
+		t.Fatalf("FindAllByUserID() after rollback = %d tasks, %v, want 0", len(tasks), err)
+	}
+}
+
+func TestRepositoryFilterExprKeepsNullRows(t *testing.T) {
+	ctx := context.Background()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	overdue := createTestTask(t, repo, &Task{UserID: userID, Title: "overdue", ProjectID: uintPtr(5), DueAt: timePtr(now.AddDate(0, 0, -1))})
+	upcoming := createTestTask(t, repo, &Task{UserID: userID, Title: "upcoming", ProjectID: uintPtr(1), DueAt: timePtr(now.AddDate(0, 0, 5))})
+	inbox := createTestTask(t, repo, &Task{UserID: userID, Title: "inbox"})
+	someday := createTestTask(t, repo, &Task{UserID: userID, Title: "someday", ProjectID: uintPtr(5)})
+
+	tests := []struct {
+		filter string
+		want   []uint
+	}{
+		{"project!=5", []uint{inbox.ID, upcoming.ID}},
+		{"NOT project:5", []uint{inbox.ID, upcoming.ID}},
+		{"NOT due<today", []uint{someday.ID, inbox.ID, upcoming.ID}},
+		{"NOT NOT due<today", []uint{overdue.ID}},
+		{"NOT (project:5 AND due<today)", []uint{someday.ID, inbox.ID, upcoming.ID}},
+		{"NOT (project:1 OR due>today)", []uint{someday.ID, inbox.ID, overdue.ID}},
+		{"due!=today", []uint{someday.ID, inbox.ID, upcoming.ID, overdue.ID}},
+		{"NOT title:day", []uint{inbox.ID, upcoming.ID, overdue.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := compileFilter(tt.filter, now, time.UTC)
+			if err != nil {
+				t.Fatalf("compileFilter() error = %v", err)
+			}
+			tasks, err := repo.FindAllByUserID(ctx, userID, Filter{Expr: expr})
+			if err != nil {
+				t.Fatalf("FindAllByUserID() error = %v", err)
+			}
+			if got := taskIDs(tasks); !slices.Equal(got, tt.want) {
+				t.Fatalf("FindAllByUserID(%s) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,7 +12,21 @@ func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
 	
 	tasks.Post("/", middlewares.Auth(cfg), ctrl.CreateTask)
 	tasks.Get("/", middlewares.Auth(cfg), ctrl.GetTasksByUserID)
+	// Route trash didaftarkan sebelum /:id agar "trash" tidak dibaca sebagai ID
+	tasks.Get("/trash", middlewares.Auth(cfg), ctrl.GetTrash)
+	tasks.Delete("/trash/:id", middlewares.Auth(cfg), ctrl.DeleteTaskPermanently)
+	tasks.Post("/unarchive", middlewares.Auth(cfg), ctrl.UnarchiveTasks)
+	tasks.Post("/bulk", middlewares.Auth(cfg), ctrl.BulkUpdate)
+	tasks.Get("/dependency-order", middlewares.Auth(cfg), ctrl.GetDependencyOrder)
 	tasks.Get("/:id", middlewares.Auth(cfg), ctrl.GetTaskByID)
 	tasks.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateTask)
 	tasks.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteTask)
+	tasks.Get("/:id/history", middlewares.Auth(cfg), ctrl.GetTaskHistory)
+	tasks.Post("/:id/revert/:revision", middlewares.Auth(cfg), ctrl.RevertTask)
+	tasks.Post("/:id/restore", middlewares.Auth(cfg), ctrl.RestoreTask)
+	tasks.Post("/:id/archive", middlewares.Auth(cfg), ctrl.ArchiveTask)
+	tasks.Post("/:id/unarchive", middlewares.Auth(cfg), ctrl.UnarchiveTask)
+	tasks.Post("/:id/move", middlewares.Auth(cfg), ctrl.MoveTask)
+	tasks.Post("/:id/dependencies", middlewares.Auth(cfg), ctrl.AddDependency)
+	tasks.Delete("/:id/dependencies/:blockedById", middlewares.Auth(cfg), ctrl.RemoveDependency)
 }
\ No newline at end of file
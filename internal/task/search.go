@@ -0,0 +1,24 @@
+package task
+
+import (
+	"context"
+	"log/slog"
+	"rest-api/internal/search"
+)
+
+// indexTask memperbarui dokumen task di search index
+// Kegagalan hanya dicatat di log agar tidak membatalkan perubahan task yang sudah tersimpan,
+// index bisa diisi ulang dengan perintah "taskctl search reindex"
+func (s *service) indexTask(ctx context.Context, task *Task) {
+	err := s.index.Index(ctx, search.Document{TaskID: task.ID, Title: task.Title, Content: task.Description})
+	if err != nil {
+		slog.WarnContext(ctx, "search index update failed", slog.Any("task_id", task.ID), slog.Any("error", err))
+	}
+}
+
+// unindexTask menghapus dokumen task yang dihapus permanen dari search index
+func (s *service) unindexTask(ctx context.Context, taskID uint) {
+	if err := s.index.Remove(ctx, taskID); err != nil {
+		slog.WarnContext(ctx, "search index removal failed", slog.Any("task_id", taskID), slog.Any("error", err))
+	}
+}
@@ -1,106 +1,141 @@
 package task
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"rest-api/internal/search"
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/config"
+	"rest-api/pkg/metrics"
+	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	CreateTask(userID uint, title, description string) (*Response, error)
-	GetTasksByUserID(userID uint) ([]Response, error)
-	GetTaskByID(userID, id uint) (*Response, error)
-	UpdateTask(userID, taskID uint, req *UpdateRequest) (*Response, error)
-	DeleteTask(userID, taskID uint) error
+	CreateTask(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetTasksByUserID(ctx context.Context, userID uint, query *ListQuery) ([]Response, error)
+	GetTaskByID(ctx context.Context, userID, id uint) (*Response, error)
+	UpdateTask(ctx context.Context, userID, taskID uint, req *UpdateRequest) (*Response, error)
+	DeleteTask(ctx context.Context, userID, taskID uint) error
+	GetTaskHistory(ctx context.Context, userID, taskID uint) ([]RevisionResponse, error)
+	RevertTask(ctx context.Context, userID, taskID uint, revision int) (*Response, error)
+	GetTrash(ctx context.Context, userID uint) ([]Response, error)
+	RestoreTask(ctx context.Context, userID, taskID uint) (*Response, error)
+	DeleteTaskPermanently(ctx context.Context, userID, taskID uint) error
+	PurgeTrash(ctx context.Context, olderThan time.Duration) (int64, error)
+	ArchiveTask(ctx context.Context, userID, taskID uint) (*Response, error)
+	UnarchiveTask(ctx context.Context, userID, taskID uint) (*Response, error)
+	UnarchiveTasks(ctx context.Context, userID uint, req *UnarchiveRequest) (int64, error)
+	AutoArchive(ctx context.Context) (int64, error)
+	BulkUpdate(ctx context.Context, userID uint, req *BulkRequest) (*BulkResponse, error)
+	MoveTask(ctx context.Context, userID, taskID uint, req *MoveRequest) (*Response, error)
+	MoveCard(ctx context.Context, userID, taskID uint, move *CardMove) (*Response, error)
+	GetCards(ctx context.Context, userID uint, columnIDs []uint) ([]Response, error)
+	AddDependency(ctx context.Context, userID, taskID uint, req *DependencyRequest) (*Response, error)
+	RemoveDependency(ctx context.Context, userID, taskID, blockedByID uint) (*Response, error)
+	GetDependencyOrder(ctx context.Context, userID, projectID uint) ([]Response, error)
 }
 
 type service struct {
-	repo Repository
+	repo  Repository
+	audit auditlog.Recorder
+	index search.SearchIndex // diperbarui setiap kali judul/deskripsi task berubah
+
+	blockCompletion bool // task tidak bisa diselesaikan selama blocker-nya belum selesai
 }
 
 // CreateTask implements Service.
-func (s *service) CreateTask(userID uint, title, description string) (*Response, error) {
-	if title == "" {
-		return nil, errors.New("title is required")
-	}
-
+func (s *service) CreateTask(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
 	task := &Task{
 		UserID:      userID,
-		Title:       title,
-		Description: description,
+		Title:       req.Title,
+		Description: req.Description,
 		IsCompleted: false,
+		Priority:    PriorityNone,
+		Tags:        toTags(req.Tags),
+	}
+	if req.Priority != "" {
+		task.Priority = req.Priority
+	}
+	task.EstimateMinutes = req.EstimateMinutes
+	task.DueAt = parseDue(req.DueAt)
+	if err := s.setProject(ctx, task, req.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := s.applyFields(ctx, task, req.CustomFields); err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.Create(task); err != nil {
-		return nil, errors.New("failed to create task")
+	// Task baru ditaruh paling atas pada urutan manual
+	position, err := s.topPosition(ctx, userID)
+	if err != nil {
+		return nil, ErrTaskCreateFailed.Wrap(err)
 	}
+	task.Position = position
 
-	response := &Response{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		IsCompleted: task.IsCompleted,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		UserID:      task.UserID,
+	if err := s.repo.Create(ctx, task); err != nil {
+		return nil, ErrTaskCreateFailed.Wrap(err)
 	}
+	metrics.TasksCreated.Inc()
+	s.indexTask(ctx, task)
+	entry := auditlog.Target(auditlog.ActionTaskCreate, auditlog.TargetTask, task.ID)
+	entry.Changes = auditlog.Diff(nil, snapshot(task))
+	s.audit.Record(ctx, entry)
 
-	return response, nil
+	return toResponse(task), nil
 }
 
 // DeleteTask implements Service.
-func (s *service) DeleteTask(userID, taskID uint) error {
-	task, err := s.repo.FindByID(taskID)
+// Task dipindahkan ke trash dan masih bisa di-restore sampai di-purge
+func (s *service) DeleteTask(ctx context.Context, userID, taskID uint) error {
+	task, err := s.findOwned(ctx, userID, taskID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("task not found")
-		}
-		return errors.New("failed to retrieve task")
-	}
-
-	if task.UserID != userID {
-		return errors.New("unauthorized to delete this task")
+		return err
 	}
 
-	if err := s.repo.Delete(task); err != nil {
-		return errors.New("failed to delete task")
+	if err := s.repo.Delete(ctx, task); err != nil {
+		return ErrTaskDeleteFailed.Wrap(err)
 	}
+	entry := auditlog.Target(auditlog.ActionTaskDelete, auditlog.TargetTask, task.ID)
+	entry.Changes = auditlog.Diff(snapshot(task), nil)
+	s.audit.Record(ctx, entry)
 
 	return nil
 }
 
 // GetTaskByID implements Service.
-func (s *service) GetTaskByID(userID, id uint) (*Response, error) {
-	task, err := s.repo.FindByID(id)
+func (s *service) GetTaskByID(ctx context.Context, userID, id uint) (*Response, error) {
+	task, err := s.findOwned(ctx, userID, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("task not found")
-		}
-		return nil, errors.New("failed to retrieve task")
+		return nil, err
 	}
 
-	if task.UserID != userID {
-		return nil, errors.New("unauthorized to access this task")
-	}
+	return toResponse(task), nil
+}
 
-	response := &Response{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		IsCompleted: task.IsCompleted,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		UserID:      task.UserID,
+// GetTasksByUserID implements Service.
+// Task yang diarsipkan tidak ikut kecuali query.Archived bernilai include atau only
+func (s *service) GetTasksByUserID(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	if query.Sort == SortPosition {
+		if err := s.ensureRanked(ctx, userID); err != nil {
+			return nil, ErrTaskFetchFailed.Wrap(err)
+		}
 	}
 
-	return response, nil
-}
+	filter, err := s.listFilter(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
 
-// GetTasksByUserID implements Service.
-func (s *service) GetTasksByUserID(userID uint) ([]Response, error) {
-	tasks, err := s.repo.FindAllByUserID(userID)
+	tasks, err := s.repo.FindAllByUserID(ctx, userID, filter)
 	if err != nil {
-		return nil, errors.New("failed to retrieve tasks")
+		return nil, ErrTaskFetchFailed.Wrap(err)
 	}
 
 	if len(tasks) == 0 {
@@ -108,34 +143,35 @@ func (s *service) GetTasksByUserID(userID uint) ([]Response, error) {
 	}
 
 	responses := make([]Response, len(tasks))
-	for i, task := range tasks {
-		responses[i] = Response{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			IsCompleted: task.IsCompleted,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
-			UserID:      task.UserID,
-		}
+	for i := range tasks {
+		responses[i] = *toResponse(&tasks[i])
 	}
 
 	return responses, nil
 }
 
 // UpdateTask implements Service.
-func (s *service) UpdateTask(userID, taskID uint, req *UpdateRequest) (*Response, error) {
-	task, err := s.repo.FindByID(taskID)
+// Setiap perubahan field disimpan sebagai revision baru di riwayat task
+// Task dikunci dan dibaca di dalam transaksi yang sama dengan penyimpanan agar dua update bersamaan
+// tidak saling menimpa field yang diubah update lain
+func (s *service) UpdateTask(ctx context.Context, userID, taskID uint, req *UpdateRequest) (*Response, error) {
+	var res *Response
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		res, err = tx.updateTask(ctx, userID, taskID, req)
+		return err
+	})
+	return res, err
+}
+
+// updateTask mengubah task, harus dipanggil di dalam transaksi (lihat UpdateTask)
+func (s *service) updateTask(ctx context.Context, userID, taskID uint, req *UpdateRequest) (*Response, error) {
+	task, err := s.lockOwned(ctx, userID, taskID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("task not found")
-		}
-		return nil, errors.New("failed to retrieve task")
+		return nil, err
 	}
 
-	if task.UserID != userID {
-		return nil, errors.New("unauthorized to update this task")
-	}
+	before := snapshot(task)
 
 	// Update fields that were provided
 	if req.Title != nil {
@@ -147,24 +183,605 @@ func (s *service) UpdateTask(userID, taskID uint, req *UpdateRequest) (*Response
 	if req.IsCompleted != nil {
 		task.IsCompleted = *req.IsCompleted
 	}
+	if req.ProjectID != nil {
+		if err := s.setProject(ctx, task, req.ProjectID); err != nil {
+			return nil, err
+		}
+		dropInapplicableFields(task)
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.Tags != nil {
+		task.Tags = toTags(*req.Tags)
+	}
+	if req.EstimateMinutes != nil {
+		task.EstimateMinutes = nil
+		if minutes := *req.EstimateMinutes; minutes > 0 {
+			task.EstimateMinutes = &minutes
+		}
+	}
+	if req.DueAt != nil {
+		task.DueAt = parseDue(*req.DueAt)
+	}
+	if err := s.applyFields(ctx, task, req.CustomFields); err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.Update(task); err != nil {
-		return nil, errors.New("failed to update task")
+	if err := s.save(ctx, userID, task, before, nil); err != nil {
+		return nil, err
 	}
 
-	response := &Response{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		IsCompleted: task.IsCompleted,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		UserID:      task.UserID,
+	return toResponse(task), nil
+}
+
+// GetTaskHistory implements Service.
+// Riwayat diurutkan dari revision terlama
+func (s *service) GetTaskHistory(ctx context.Context, userID, taskID uint) ([]RevisionResponse, error) {
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindRevisions(ctx, task.ID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	responses := make([]RevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		changes, err := decodeChanges(revision.Changes)
+		if err != nil {
+			return nil, ErrTaskFetchFailed.Wrap(err)
+		}
+		responses[i] = RevisionResponse{
+			Revision:   revision.Revision,
+			UserID:     revision.UserID,
+			Changes:    changes,
+			RevertedTo: revision.RevertedTo,
+			CreatedAt:  revision.CreatedAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// RevertTask implements Service.
+// Mengembalikan task ke state setelah revision tertentu (0 = state saat dibuat)
+// Revert disimpan sebagai revision baru sehingga bisa di-revert lagi
+// Seperti UpdateTask, task dan riwayatnya dibaca di dalam transaksi setelah task dikunci
+func (s *service) RevertTask(ctx context.Context, userID, taskID uint, revision int) (*Response, error) {
+	var res *Response
+	err := s.transaction(ctx, func(tx *service) error {
+		var err error
+		res, err = tx.revertTask(ctx, userID, taskID, revision)
+		return err
+	})
+	return res, err
+}
+
+// revertTask mengembalikan task, harus dipanggil di dalam transaksi (lihat RevertTask)
+func (s *service) revertTask(ctx context.Context, userID, taskID uint, revision int) (*Response, error) {
+	task, err := s.lockOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindRevisions(ctx, task.ID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	latest := 0
+	if len(revisions) > 0 {
+		latest = revisions[len(revisions)-1].Revision
+	}
+	if revision < 0 || revision > latest {
+		return nil, ErrRevisionNotFound
+	}
+
+	// Batalkan revision setelah target satu per satu, mulai dari yang terbaru
+	state := snapshot(task)
+	for i := len(revisions) - 1; i >= 0 && revisions[i].Revision > revision; i-- {
+		changes, err := decodeChanges(revisions[i].Changes)
+		if err != nil {
+			return nil, ErrTaskFetchFailed.Wrap(err)
+		}
+		for field, change := range changes {
+			state[field] = change.From
+		}
+	}
+
+	before := snapshot(task)
+	restore(task, state)
+
+	// Project yang sudah dihapus tidak bisa dikembalikan, task dikeluarkan dari project
+	if task.ProjectID != nil {
+		exists, err := s.repo.ProjectExists(ctx, userID, *task.ProjectID)
+		if err != nil {
+			return nil, ErrTaskFetchFailed.Wrap(err)
+		}
+		if !exists {
+			task.ProjectID = nil
+		}
+	}
+	if err := s.restoreFields(ctx, task, state["customFields"]); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, userID, task, before, &revision); err != nil {
+		return nil, err
+	}
+
+	return toResponse(task), nil
+}
+
+// GetTrash implements Service.
+func (s *service) GetTrash(ctx context.Context, userID uint) ([]Response, error) {
+	tasks, err := s.repo.FindDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(tasks))
+	for i := range tasks {
+		responses[i] = *toResponse(&tasks[i])
+	}
+
+	return responses, nil
+}
+
+// RestoreTask implements Service.
+func (s *service) RestoreTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	task, err := s.findTrashed(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, task); err != nil {
+		return nil, ErrTaskRestoreFailed.Wrap(err)
+	}
+	s.audit.Record(ctx, auditlog.Target(auditlog.ActionTaskRestore, auditlog.TargetTask, task.ID))
+
+	return toResponse(task), nil
+}
+
+// DeleteTaskPermanently implements Service.
+// Hanya task yang sudah ada di trash yang bisa dihapus permanen
+func (s *service) DeleteTaskPermanently(ctx context.Context, userID, taskID uint) error {
+	task, err := s.findTrashed(ctx, userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePermanently(ctx, task); err != nil {
+		return ErrTaskDeleteFailed.Wrap(err)
+	}
+	s.unindexTask(ctx, task.ID)
+	s.audit.Record(ctx, auditlog.Target(auditlog.ActionTaskPurge, auditlog.TargetTask, task.ID))
+
+	return nil
+}
+
+// PurgeTrash implements Service.
+// Menghapus permanen task yang sudah berada di trash lebih lama dari olderThan, olderThan <= 0 tidak menghapus apa pun
+func (s *service) PurgeTrash(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan <= 0 {
+		return 0, nil
+	}
+
+	count, err := s.repo.PurgeDeletedBefore(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, ErrTaskPurgeFailed.Wrap(err)
+	}
+	if count > 0 {
+		slog.InfoContext(ctx, "task trash purged", slog.Int64("deleted", count))
+		if _, err := s.index.Prune(ctx); err != nil {
+			slog.WarnContext(ctx, "search index prune failed", slog.Any("error", err))
+		}
+	}
+
+	return count, nil
+}
+
+// ArchiveTask implements Service.
+// Arsip tidak mengubah status selesai, task tetap bisa diambil dan diubah lewat ID
+func (s *service) ArchiveTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	return s.setArchived(ctx, userID, taskID, true)
+}
+
+// UnarchiveTask implements Service.
+func (s *service) UnarchiveTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	return s.setArchived(ctx, userID, taskID, false)
+}
+
+// UnarchiveTasks implements Service.
+// ID milik user lain atau yang tidak diarsipkan diabaikan, mengembalikan jumlah task yang dikeluarkan dari arsip
+func (s *service) UnarchiveTasks(ctx context.Context, userID uint, req *UnarchiveRequest) (int64, error) {
+	ids := req.IDs
+	if req.All {
+		ids = nil
+	} else if len(ids) == 0 {
+		return 0, nil
+	}
+
+	count, err := s.repo.Unarchive(ctx, userID, ids)
+	if err != nil {
+		return 0, ErrTaskUpdateFailed.Wrap(err)
 	}
 
-	return response, nil
+	if count > 0 {
+		s.audit.Record(ctx, auditlog.Entry{
+			Action:     auditlog.ActionTaskUnarchive,
+			TargetType: auditlog.TargetTask,
+			Metadata:   map[string]interface{}{"ids": ids, "all": req.All, "count": count},
+		})
+	}
+
+	return count, nil
+}
+
+// AutoArchive implements Service.
+// Dijalankan oleh background worker sesuai preferensi auto_archive_days setiap user
+func (s *service) AutoArchive(ctx context.Context) (int64, error) {
+	count, err := s.repo.ArchiveCompleted(ctx, time.Now().UTC())
+	if err != nil {
+		return count, ErrTaskUpdateFailed.Wrap(err)
+	}
+	if count > 0 {
+		slog.InfoContext(ctx, "completed tasks auto-archived", slog.Int64("archived", count))
+	}
+
+	return count, nil
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
-}
\ No newline at end of file
+// setArchived mengarsipkan atau mengeluarkan task dari arsip, tidak menyimpan apa pun jika state sudah sama
+func (s *service) setArchived(ctx context.Context, userID, taskID uint, archived bool) (*Response, error) {
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if (task.ArchivedAt != nil) == archived {
+		return toResponse(task), nil
+	}
+
+	action := auditlog.ActionTaskUnarchive
+	task.ArchivedAt = nil
+	if archived {
+		action = auditlog.ActionTaskArchive
+		now := time.Now().UTC()
+		task.ArchivedAt = &now
+	}
+
+	if err := s.repo.Update(ctx, task, nil); err != nil {
+		return nil, ErrTaskUpdateFailed.Wrap(err)
+	}
+	s.audit.Record(ctx, auditlog.Target(action, auditlog.TargetTask, task.ID))
+
+	return toResponse(task), nil
+}
+
+// findTrashed mengambil task di trash dan memastikan task milik userID
+func (s *service) findTrashed(ctx context.Context, userID, taskID uint) (*Task, error) {
+	task, err := s.repo.FindDeletedByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotInTrash
+		}
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	if task.UserID != userID {
+		return nil, ErrTaskForbidden
+	}
+
+	return task, nil
+}
+
+// setProject memindahkan task ke project milik user yang sama, projectID 0 atau nil mengeluarkan task dari project
+func (s *service) setProject(ctx context.Context, task *Task, projectID *uint) error {
+	if projectID == nil || *projectID == 0 {
+		task.ProjectID = nil
+		return nil
+	}
+
+	exists, err := s.repo.ProjectExists(ctx, task.UserID, *projectID)
+	if err != nil {
+		return ErrTaskFetchFailed.Wrap(err)
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+
+	id := *projectID
+	task.ProjectID = &id
+	return nil
+}
+
+// findOwned mengambil task dan memastikan task milik userID
+func (s *service) findOwned(ctx context.Context, userID, taskID uint) (*Task, error) {
+	task, err := s.repo.FindByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+
+	if task.UserID != userID {
+		return nil, ErrTaskForbidden
+	}
+
+	return task, nil
+}
+
+// lockOwned mengunci task lalu mengambilnya seperti findOwned, harus dipanggil di dalam transaksi
+// Dipakai sebelum task diubah dan disimpan kembali dengan save
+func (s *service) lockOwned(ctx context.Context, userID, taskID uint) (*Task, error) {
+	if err := s.repo.LockTask(ctx, taskID); err != nil {
+		return nil, ErrTaskFetchFailed.Wrap(err)
+	}
+	return s.findOwned(ctx, userID, taskID)
+}
+
+// save menyimpan task beserta revision dan audit log untuk field yang berubah
+// revertedTo diisi jika perubahan berasal dari RevertTask
+func (s *service) save(ctx context.Context, userID uint, task *Task, before map[string]interface{}, revertedTo *int) error {
+	changes := auditlog.Diff(before, snapshot(task))
+
+	// completed_at mengikuti perubahan status selesai
+	wasCompleted, _ := before["isCompleted"].(bool)
+	if !wasCompleted && task.IsCompleted {
+		if err := s.checkBlockers(ctx, task.ID); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		task.CompletedAt = &now
+	} else if wasCompleted && !task.IsCompleted {
+		task.CompletedAt = nil
+	}
+
+	var revision *Revision
+	if len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return ErrTaskUpdateFailed.Wrap(err)
+		}
+		revision = &Revision{UserID: &userID, Changes: string(data), RevertedTo: revertedTo}
+	}
+
+	if err := s.repo.Update(ctx, task, revision); err != nil {
+		return ErrTaskUpdateFailed.Wrap(err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !wasCompleted && task.IsCompleted {
+		metrics.TasksCompleted.Inc()
+	}
+	_, titleChanged := changes["title"]
+	_, descriptionChanged := changes["description"]
+	if titleChanged || descriptionChanged {
+		s.indexTask(ctx, task)
+	}
+
+	entry := auditlog.Target(auditlog.ActionTaskUpdate, auditlog.TargetTask, task.ID)
+	entry.Changes = changes
+	if revertedTo != nil {
+		entry.Metadata = map[string]interface{}{"revertedTo": *revertedTo}
+	}
+	s.audit.Record(ctx, entry)
+
+	return nil
+}
+
+// transaction menjalankan fn dengan service yang semua query-nya memakai satu transaksi database
+// Audit log dan search index ditunda sampai transaksi commit agar perubahan yang di-rollback tidak tercatat
+// Error selain apperror (contoh: commit gagal) dikembalikan sebagai ErrTaskUpdateFailed
+func (s *service) transaction(ctx context.Context, fn func(tx *service) error) error {
+	audit := &bufferedRecorder{}
+	index := &bufferedIndex{SearchIndex: s.index}
+	err := s.repo.Transaction(ctx, func(repo Repository) error {
+		return fn(&service{repo: repo, audit: audit, index: index, blockCompletion: s.blockCompletion})
+	})
+	if err != nil {
+		if apperror.Code(err) == "" {
+			return ErrTaskUpdateFailed.Wrap(err)
+		}
+		return err
+	}
+
+	audit.flush(ctx, s.audit)
+	index.flush(ctx)
+	return nil
+}
+
+// snapshot mengambil field task yang dicatat di revision dan audit log
+func snapshot(task *Task) map[string]interface{} {
+	var projectID, estimate, due interface{}
+	if task.ProjectID != nil {
+		projectID = *task.ProjectID
+	}
+	if task.EstimateMinutes != nil {
+		estimate = *task.EstimateMinutes
+	}
+	if task.DueAt != nil {
+		due = task.DueAt.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		"title":           task.Title,
+		"description":     task.Description,
+		"isCompleted":     task.IsCompleted,
+		"projectId":       projectID,
+		"priority":        task.Priority,
+		"tags":            tagNames(task),
+		"estimateMinutes": estimate,
+		"customFields":    fieldTexts(task),
+		"dueAt":           due,
+	}
+}
+
+// restore mengisi field task dari snapshot, kebalikan dari snapshot
+func restore(task *Task, state map[string]interface{}) {
+	if title, ok := state["title"].(string); ok {
+		task.Title = title
+	}
+	if description, ok := state["description"].(string); ok {
+		task.Description = description
+	}
+	if completed, ok := state["isCompleted"].(bool); ok {
+		task.IsCompleted = completed
+	}
+	if priority, ok := state["priority"].(string); ok {
+		task.Priority = priority
+	}
+
+	// Nilai dari revision adalah hasil decode JSON (float64 dan []interface{})
+	if value, ok := state["projectId"]; ok {
+		switch projectID := value.(type) {
+		case uint:
+			task.ProjectID = &projectID
+		case float64:
+			id := uint(projectID)
+			task.ProjectID = &id
+		case nil:
+			task.ProjectID = nil
+		}
+	}
+	if value, ok := state["estimateMinutes"]; ok {
+		switch minutes := value.(type) {
+		case int:
+			task.EstimateMinutes = &minutes
+		case float64:
+			estimate := int(minutes)
+			task.EstimateMinutes = &estimate
+		case nil:
+			task.EstimateMinutes = nil
+		}
+	}
+	if value, ok := state["dueAt"]; ok {
+		switch due := value.(type) {
+		case string:
+			task.DueAt = parseDue(due)
+		case nil:
+			task.DueAt = nil
+		}
+	}
+	switch tags := state["tags"].(type) {
+	case []string:
+		task.Tags = toTags(tags)
+	case []interface{}:
+		names := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if name, ok := tag.(string); ok {
+				names = append(names, name)
+			}
+		}
+		task.Tags = toTags(names)
+	}
+}
+
+// toTags menormalkan nama tag (trim, lowercase, unik, urut)
+func toTags(names []string) []Tag {
+	seen := make(map[string]bool, len(names))
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, Tag{Tag: name})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Tag < tags[j].Tag })
+	return tags
+}
+
+// tagNames mengembalikan nama tag task, selalu non-nil agar konsisten di JSON dan snapshot
+func tagNames(task *Task) []string {
+	names := make([]string, len(task.Tags))
+	for i, tag := range task.Tags {
+		names[i] = tag.Tag
+	}
+	return names
+}
+
+// filter mengubah query list menjadi Filter repository
+func (q *ListQuery) filter() Filter {
+	return Filter{
+		Archived:  q.Archived,
+		ProjectID: q.ProjectID,
+		Tag:       strings.ToLower(strings.TrimSpace(q.Tag)),
+		Priority:  q.Priority,
+		Completed: q.Completed,
+		Sort:      q.Sort,
+		Search:    strings.TrimSpace(q.Search),
+	}
+}
+
+// listFilter mengubah query list menjadi Filter repository
+// Key custom field di-resolve ke ID field, filter DSL dikompilasi dan tanggal relatif dihitung dari waktu sekarang
+func (s *service) listFilter(ctx context.Context, userID uint, q *ListQuery) (Filter, error) {
+	filter := q.filter()
+	now := time.Now()
+	applyDue(q, &filter, now)
+	if q.Filter != "" {
+		expr, err := compileFilter(q.Filter, now, q.location())
+		if err != nil {
+			return filter, err
+		}
+		filter.Expr = expr
+	}
+	if err := s.applyFieldQuery(ctx, userID, q, &filter); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// decodeChanges membaca kolom changes revision
+func decodeChanges(data string) (auditlog.Changes, error) {
+	var changes auditlog.Changes
+	if err := json.Unmarshal([]byte(data), &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func toResponse(task *Task) *Response {
+	response := &Response{
+		ID:              task.ID,
+		Title:           task.Title,
+		Description:     task.Description,
+		IsCompleted:     task.IsCompleted,
+		ProjectID:       task.ProjectID,
+		Priority:        task.Priority,
+		EstimateMinutes: task.EstimateMinutes,
+		Tags:            tagNames(task),
+		BlockedBy:       blockerIDs(task),
+		Blocks:          blockedIDs(task),
+		CustomFields:    fieldValues(task),
+		Position:        task.Position,
+		ColumnID:        task.ColumnID,
+		DueAt:           task.DueAt,
+		CompletedAt:     task.CompletedAt,
+		ArchivedAt:      task.ArchivedAt,
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+		UserID:          task.UserID,
+	}
+	if task.DeletedAt.Valid {
+		response.DeletedAt = &task.DeletedAt.Time
+	}
+	return response
+}
+
+func NewService(repo Repository, cfg *config.Config, audit auditlog.Recorder, index search.SearchIndex) Service {
+	return withTracing(&service{
+		repo:            repo,
+		audit:           audit,
+		index:           index,
+		blockCompletion: cfg.DependencyBlocksCompletion == "true",
+	})
+}
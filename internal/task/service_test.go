@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"rest-api/internal/search"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// newTestService membuat Service (dengan tracing) di atas database test
+func newTestService(t *testing.T) (Service, *gorm.DB, uint) {
+	t.Helper()
+	db, repo := newTestRepository(t)
+	userID := createTestUser(t, db, "budi")
+	cfg := &config.Config{DependencyBlocksCompletion: "true"}
+	return NewService(repo, cfg, auditlog.Nop(), search.NewIndex(db)), db, userID
+}
+
+func TestUpdateTaskKeepsConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	svc, _, userID := newTestService(t)
+	created, err := svc.CreateTask(ctx, userID, &CreateRequest{Title: "Laporan"})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	// Setiap goroutine mengubah field yang berbeda, tidak boleh ada perubahan yang tertimpa snapshot lama
+	const count = 10
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, count)
+	for i := range count {
+		wg.Go(func() {
+			<-start
+			if i%2 == 0 {
+				_, errs[i] = svc.UpdateTask(ctx, userID, created.ID, &UpdateRequest{Tags: &[]string{fmt.Sprintf("tag-%d", i)}})
+			} else {
+				_, errs[i] = svc.UpdateTask(ctx, userID, created.ID, &UpdateRequest{Description: strPtr(fmt.Sprintf("versi %d", i))})
+			}
+		})
+	}
+	close(start)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+	}
+
+	// Revision terakhir harus dibuat dari state yang ditulis revision sebelumnya
+	history, err := svc.GetTaskHistory(ctx, userID, created.ID)
+	if err != nil {
+		t.Fatalf("GetTaskHistory() error = %v", err)
+	}
+	if len(history) != count {
+		t.Fatalf("GetTaskHistory() = %d revisions, want %d", len(history), count)
+	}
+	state := map[string]interface{}{"description": "", "tags": []interface{}{}}
+	for _, revision := range history {
+		for field, change := range revision.Changes {
+			if from := fmt.Sprint(change.From); from != fmt.Sprint(state[field]) {
+				t.Fatalf("revision %d %s from = %s, want %v (lost update)", revision.Revision, field, from, state[field])
+			}
+			state[field] = change.To
+		}
+	}
+
+	task, err := svc.GetTaskByID(ctx, userID, created.ID)
+	if err != nil {
+		t.Fatalf("GetTaskByID() error = %v", err)
+	}
+	if task.Description != state["description"] || fmt.Sprint(task.Tags) != fmt.Sprint(state["tags"]) {
+		t.Errorf("task = %q %v, want %v", task.Description, task.Tags, state)
+	}
+}
@@ -0,0 +1,194 @@
+package task
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// CreateTask implements Service.
+func (t *tracedService) CreateTask(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.CreateTask", attribute.Int("user.id", int(userID)))
+	res, err := t.next.CreateTask(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetTasksByUserID implements Service.
+func (t *tracedService) GetTasksByUserID(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "task.GetTasksByUserID", attribute.Int("user.id", int(userID)), attribute.String("task.archived", query.Archived))
+	res, err := t.next.GetTasksByUserID(ctx, userID, query)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetTaskByID implements Service.
+func (t *tracedService) GetTaskByID(ctx context.Context, userID, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.GetTaskByID", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(id)))
+	res, err := t.next.GetTaskByID(ctx, userID, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateTask implements Service.
+func (t *tracedService) UpdateTask(ctx context.Context, userID, taskID uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.UpdateTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.UpdateTask(ctx, userID, taskID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteTask implements Service.
+func (t *tracedService) DeleteTask(ctx context.Context, userID, taskID uint) error {
+	ctx, span := tracing.Start(ctx, "task.DeleteTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	err := t.next.DeleteTask(ctx, userID, taskID)
+	tracing.End(span, err)
+	return err
+}
+
+// GetTaskHistory implements Service.
+func (t *tracedService) GetTaskHistory(ctx context.Context, userID, taskID uint) ([]RevisionResponse, error) {
+	ctx, span := tracing.Start(ctx, "task.GetTaskHistory", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.GetTaskHistory(ctx, userID, taskID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// RevertTask implements Service.
+func (t *tracedService) RevertTask(ctx context.Context, userID, taskID uint, revision int) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.RevertTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)), attribute.Int("task.revision", revision))
+	res, err := t.next.RevertTask(ctx, userID, taskID, revision)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetTrash implements Service.
+func (t *tracedService) GetTrash(ctx context.Context, userID uint) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "task.GetTrash", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetTrash(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// RestoreTask implements Service.
+func (t *tracedService) RestoreTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.RestoreTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.RestoreTask(ctx, userID, taskID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteTaskPermanently implements Service.
+func (t *tracedService) DeleteTaskPermanently(ctx context.Context, userID, taskID uint) error {
+	ctx, span := tracing.Start(ctx, "task.DeleteTaskPermanently", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	err := t.next.DeleteTaskPermanently(ctx, userID, taskID)
+	tracing.End(span, err)
+	return err
+}
+
+// PurgeTrash implements Service.
+func (t *tracedService) PurgeTrash(ctx context.Context, olderThan time.Duration) (int64, error) {
+	ctx, span := tracing.Start(ctx, "task.PurgeTrash", attribute.String("task.retention", olderThan.String()))
+	res, err := t.next.PurgeTrash(ctx, olderThan)
+	tracing.End(span, err)
+	return res, err
+}
+
+// ArchiveTask implements Service.
+func (t *tracedService) ArchiveTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.ArchiveTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.ArchiveTask(ctx, userID, taskID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UnarchiveTask implements Service.
+func (t *tracedService) UnarchiveTask(ctx context.Context, userID, taskID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.UnarchiveTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.UnarchiveTask(ctx, userID, taskID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UnarchiveTasks implements Service.
+func (t *tracedService) UnarchiveTasks(ctx context.Context, userID uint, req *UnarchiveRequest) (int64, error) {
+	ctx, span := tracing.Start(ctx, "task.UnarchiveTasks", attribute.Int("user.id", int(userID)), attribute.Int("task.count", len(req.IDs)), attribute.Bool("task.all", req.All))
+	res, err := t.next.UnarchiveTasks(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// AutoArchive implements Service.
+func (t *tracedService) AutoArchive(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "task.AutoArchive")
+	res, err := t.next.AutoArchive(ctx)
+	tracing.End(span, err)
+	return res, err
+}
+
+// BulkUpdate implements Service.
+func (t *tracedService) BulkUpdate(ctx context.Context, userID uint, req *BulkRequest) (*BulkResponse, error) {
+	ctx, span := tracing.Start(ctx, "task.BulkUpdate", attribute.Int("user.id", int(userID)), attribute.String("task.operation", req.Operation), attribute.Int("task.count", len(req.IDs)))
+	res, err := t.next.BulkUpdate(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// MoveTask implements Service.
+func (t *tracedService) MoveTask(ctx context.Context, userID, taskID uint, req *MoveRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.MoveTask", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)))
+	res, err := t.next.MoveTask(ctx, userID, taskID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// MoveCard implements Service.
+func (t *tracedService) MoveCard(ctx context.Context, userID, taskID uint, move *CardMove) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.MoveCard", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)), attribute.Int("board.column_id", int(move.ColumnID)))
+	res, err := t.next.MoveCard(ctx, userID, taskID, move)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetCards implements Service.
+func (t *tracedService) GetCards(ctx context.Context, userID uint, columnIDs []uint) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "task.GetCards", attribute.Int("user.id", int(userID)), attribute.Int("board.columns", len(columnIDs)))
+	res, err := t.next.GetCards(ctx, userID, columnIDs)
+	tracing.End(span, err)
+	return res, err
+}
+
+// AddDependency implements Service.
+func (t *tracedService) AddDependency(ctx context.Context, userID, taskID uint, req *DependencyRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.AddDependency", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)), attribute.Int("task.blocked_by_id", int(req.BlockedByID)))
+	res, err := t.next.AddDependency(ctx, userID, taskID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// RemoveDependency implements Service.
+func (t *tracedService) RemoveDependency(ctx context.Context, userID, taskID, blockedByID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "task.RemoveDependency", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(taskID)), attribute.Int("task.blocked_by_id", int(blockedByID)))
+	res, err := t.next.RemoveDependency(ctx, userID, taskID, blockedByID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetDependencyOrder implements Service.
+func (t *tracedService) GetDependencyOrder(ctx context.Context, userID, projectID uint) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "task.GetDependencyOrder", attribute.Int("user.id", int(userID)), attribute.Int("project.id", int(projectID)))
+	res, err := t.next.GetDependencyOrder(ctx, userID, projectID)
+	tracing.End(span, err)
+	return res, err
+}
@@ -0,0 +1,51 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestServiceSpansWrapQueries(t *testing.T) {
+	// Provider dipasang setelah database test siap agar query migration tidak ikut terekam
+	svc, _, userID := newTestService(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracing.NewProvider(exporter, "test", 1)
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+
+	ctx, request := tracing.Start(context.Background(), "HTTP GET /api/tasks/")
+	if _, err := svc.GetTasksByUserID(ctx, userID, &ListQuery{}); err != nil {
+		t.Fatalf("GetTasksByUserID() error = %v", err)
+	}
+	request.End()
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+
+	var service tracetest.SpanStub
+	var queries []tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		switch span.Name {
+		case "task.GetTasksByUserID":
+			service = span
+		case "gorm.query":
+			queries = append(queries, span)
+		}
+	}
+
+	if service.Parent.SpanID() != request.SpanContext().SpanID() {
+		t.Fatalf("service span parent = %s, want request span %s", service.Parent.SpanID(), request.SpanContext().SpanID())
+	}
+	if len(queries) == 0 {
+		t.Fatal("no gorm.query span recorded")
+	}
+	for _, query := range queries {
+		if query.Parent.SpanID() != service.SpanContext.SpanID() {
+			t.Errorf("gorm span parent = %s, want service span %s", query.Parent.SpanID(), service.SpanContext.SpanID())
+		}
+	}
+}
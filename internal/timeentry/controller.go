@@ -0,0 +1,256 @@
+package timeentry
+
+import (
+	"bytes"
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Start timer
+// @Description Mulai timer untuk task, setiap user hanya bisa punya satu timer berjalan
+// @Tags Time Entries
+// @Accept json
+// @Produce json
+// @Param data body StartRequest true "Task"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries/timer/start [post]
+func (ctrl *Controller) StartTimer(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req StartRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	entry, err := ctrl.service.StartTimer(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "TIMER_STARTED", fiber.Map{
+		"entry": entry,
+	})
+}
+
+// @Summary Stop timer
+// @Description Hentikan timer yang sedang berjalan dan simpan durasinya
+// @Tags Time Entries
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/time-entries/timer/stop [post]
+func (ctrl *Controller) StopTimer(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	entry, err := ctrl.service.StopTimer(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIMER_STOPPED", fiber.Map{
+		"entry": entry,
+	})
+}
+
+// @Summary Get running timer
+// @Description Timer yang sedang berjalan, entry bernilai null jika tidak ada
+// @Tags Time Entries
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/time-entries/timer [get]
+func (ctrl *Controller) GetRunningTimer(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	entry, err := ctrl.service.GetRunningTimer(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIMER_RETRIEVED", fiber.Map{
+		"entry": entry,
+	})
+}
+
+// @Summary Create time entry
+// @Description Catat waktu kerja secara manual dengan durasi dalam menit, startedAt default durationMinutes sebelum sekarang
+// @Tags Time Entries
+// @Accept json
+// @Produce json
+// @Param data body CreateRequest true "Time entry"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries [post]
+func (ctrl *Controller) CreateEntry(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req CreateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	entry, err := ctrl.service.CreateEntry(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "TIME_ENTRY_CREATED", fiber.Map{
+		"entry": entry,
+	})
+}
+
+// @Summary List time entries
+// @Description Time entry milik user dari yang terbaru, filter taskId, projectId dan rentang from/to (RFC 3339) bersifat opsional
+// @Tags Time Entries
+// @Produce json
+// @Param query query ListQuery false "Filter"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries [get]
+func (ctrl *Controller) GetEntries(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	entries, err := ctrl.service.GetEntries(c.UserContext(), user.ID, &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIME_ENTRIES_RETRIEVED", fiber.Map{
+		"entries": entries,
+	})
+}
+
+// @Summary Update time entry
+// @Description Ubah waktu mulai, durasi atau note. Waktu tidak bisa diubah selama timer masih berjalan
+// @Tags Time Entries
+// @Accept json
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Param data body UpdateRequest true "Time entry"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries/{id} [put]
+func (ctrl *Controller) UpdateEntry(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidEntryID
+	}
+
+	var req UpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	entry, err := ctrl.service.UpdateEntry(c.UserContext(), user.ID, uint(entryID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIME_ENTRY_UPDATED", fiber.Map{
+		"entry": entry,
+	})
+}
+
+// @Summary Delete time entry
+// @Description Hapus time entry, termasuk timer yang sedang berjalan
+// @Tags Time Entries
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/time-entries/{id} [delete]
+func (ctrl *Controller) DeleteEntry(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidEntryID
+	}
+
+	if err := ctrl.service.DeleteEntry(c.UserContext(), user.ID, uint(entryID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIME_ENTRY_DELETED", fiber.Map{})
+}
+
+// @Summary Time summary
+// @Description Total waktu (detik) per task dan per project untuk filter yang sama dengan list, timer yang masih berjalan tidak dihitung
+// @Tags Time Entries
+// @Produce json
+// @Param query query ListQuery false "Filter"
+// @Success 200 {object} response.SuccessResponse{data=SummaryResponse}
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries/summary [get]
+func (ctrl *Controller) GetSummary(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	summary, err := ctrl.service.GetSummary(c.UserContext(), user.ID, &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TIME_SUMMARY_RETRIEVED", summary)
+}
+
+// @Summary Export time entries
+// @Description Export time entry yang sudah berhenti sebagai CSV untuk invoice, filter sama dengan list
+// @Tags Time Entries
+// @Produce text/csv
+// @Param query query ListQuery false "Filter"
+// @Success 200 {file} file
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/time-entries/export [get]
+func (ctrl *Controller) ExportCSV(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query ListQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := ctrl.service.ExportCSV(c.UserContext(), user.ID, &query, &buf); err != nil {
+		return err
+	}
+
+	c.Attachment("time-entries.csv")
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
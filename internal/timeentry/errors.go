@@ -0,0 +1,16 @@
+package timeentry
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul time entry
+var (
+	ErrEntryNotFound       = apperror.NotFound("TIME_ENTRY_NOT_FOUND", "time entry not found")
+	ErrEntryForbidden      = apperror.Forbidden("TIME_ENTRY_FORBIDDEN", "unauthorized to access this time entry")
+	ErrInvalidEntryID      = apperror.BadRequest("INVALID_TIME_ENTRY_ID", "Invalid time entry ID")
+	ErrTimerAlreadyRunning = apperror.Conflict("TIMER_ALREADY_RUNNING", "another timer is already running")
+	ErrNoRunningTimer      = apperror.NotFound("NO_RUNNING_TIMER", "no timer is running")
+	ErrEntryRunning        = apperror.Conflict("TIME_ENTRY_RUNNING", "stop the timer before changing its time")
+	ErrEntryFetchFailed    = apperror.Internal("TIME_ENTRY_FETCH_FAILED", "failed to retrieve time entries", nil)
+	ErrEntrySaveFailed     = apperror.Internal("TIME_ENTRY_SAVE_FAILED", "failed to save time entry", nil)
+	ErrEntryDeleteFailed   = apperror.Internal("TIME_ENTRY_DELETE_FAILED", "failed to delete time entry", nil)
+)
@@ -0,0 +1,93 @@
+package timeentry
+
+import "time"
+
+// Entry adalah waktu kerja user pada satu task, dari timer atau dicatat manual
+// EndedAt nil berarti timer masih berjalan, setiap user hanya boleh punya satu timer berjalan
+type Entry struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"not null"`
+	TaskID    uint       `gorm:"not null"`
+	StartedAt time.Time  `gorm:"not null"`
+	EndedAt   *time.Time // nil selama timer berjalan
+	Duration  int        `gorm:"not null"` // detik, 0 selama timer berjalan
+	Note      string     `gorm:"size:500;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Entry) TableName() string {
+	return "time_entries"
+}
+
+// Query DTOs
+// Waktu memakai format RFC 3339 (contoh: 2025-01-31T00:00:00Z), from inklusif dan to eksklusif terhadap started_at
+type ListQuery struct {
+	TaskID    *uint  `json:"taskId" query:"taskId"`
+	ProjectID *uint  `json:"projectId" query:"projectId"`
+	From      string `json:"from" query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `json:"to" query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// Request DTOs
+type StartRequest struct {
+	TaskID uint   `json:"taskId" validate:"required"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// CreateRequest mencatat waktu kerja secara manual, startedAt default durationMinutes sebelum sekarang
+type CreateRequest struct {
+	TaskID          uint   `json:"taskId" validate:"required"`
+	StartedAt       string `json:"startedAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DurationMinutes int    `json:"durationMinutes" validate:"required,min=1,max=1440"`
+	Note            string `json:"note" validate:"max=500"`
+}
+
+// Field pointer bernilai nil jika tidak dikirim, startedAt dan durationMinutes hanya untuk entry yang sudah berhenti
+type UpdateRequest struct {
+	StartedAt       *string `json:"startedAt" validate:"omitnil,datetime=2006-01-02T15:04:05Z07:00"`
+	DurationMinutes *int    `json:"durationMinutes" validate:"omitnil,min=1,max=1440"`
+	Note            *string `json:"note" validate:"omitnil,max=500"`
+}
+
+// Response DTOs
+type Response struct {
+	ID              uint       `json:"id"`
+	TaskID          uint       `json:"taskId"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt"`
+	DurationSeconds int        `json:"durationSeconds"`
+	Running         bool       `json:"running"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	UserID          uint       `json:"userId"`
+}
+
+// SummaryResponse adalah total waktu entry yang sudah berhenti, per task dan per project
+type SummaryResponse struct {
+	TotalSeconds int64          `json:"totalSeconds"`
+	Tasks        []TaskTotal    `json:"tasks"`
+	Projects     []ProjectTotal `json:"projects"`
+}
+
+type TaskTotal struct {
+	TaskID          uint   `json:"taskId"`
+	Title           string `json:"title"`
+	EstimateMinutes *int   `json:"estimateMinutes"`
+	Seconds         int64  `json:"seconds"`
+}
+
+// ProjectTotal dengan projectId null adalah total task yang tidak ada di project manapun
+type ProjectTotal struct {
+	ProjectID *uint  `json:"projectId"`
+	Name      string `json:"name"`
+	Seconds   int64  `json:"seconds"`
+}
+
+// ExportRow adalah satu baris CSV export
+type ExportRow struct {
+	Entry
+	TaskTitle   string
+	ProjectName *string
+}
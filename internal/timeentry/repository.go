@@ -0,0 +1,158 @@
+package timeentry
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Filter adalah kriteria entry milik user, field nil diabaikan
+type Filter struct {
+	TaskID    *uint
+	ProjectID *uint
+	From      *time.Time
+	To        *time.Time
+	Stopped   bool // hanya entry yang sudah berhenti, dipakai untuk total dan export
+}
+
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	Update(ctx context.Context, entry *Entry) error
+	Delete(ctx context.Context, entry *Entry) error
+	FindByID(ctx context.Context, id uint) (*Entry, error)
+	FindRunning(ctx context.Context, userID uint) (*Entry, error)
+	FindAll(ctx context.Context, userID uint, filter Filter) ([]Entry, error)
+	TotalsByTask(ctx context.Context, userID uint, filter Filter) ([]TaskTotal, error)
+	TotalsByProject(ctx context.Context, userID uint, filter Filter) ([]ProjectTotal, error)
+	FindExportRows(ctx context.Context, userID uint, filter Filter) ([]ExportRow, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+func (r *repository) Create(ctx context.Context, entry *Entry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// Update implements Repository.
+func (r *repository) Update(ctx context.Context, entry *Entry) error {
+	return r.db.WithContext(ctx).Save(entry).Error
+}
+
+// Delete implements Repository.
+func (r *repository) Delete(ctx context.Context, entry *Entry) error {
+	return r.db.WithContext(ctx).Delete(entry).Error
+}
+
+// FindByID implements Repository.
+func (r *repository) FindByID(ctx context.Context, id uint) (*Entry, error) {
+	var entry Entry
+	if err := r.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindRunning implements Repository.
+// Mengembalikan gorm.ErrRecordNotFound jika user tidak punya timer berjalan
+func (r *repository) FindRunning(ctx context.Context, userID uint) (*Entry, error) {
+	var entry Entry
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindAll implements Repository.
+// Entry diurutkan dari yang terbaru
+func (r *repository) FindAll(ctx context.Context, userID uint, filter Filter) ([]Entry, error) {
+	var entries []Entry
+	if err := r.db.WithContext(ctx).
+		Scopes(filter.scope(userID)).
+		Order("time_entries.started_at desc").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// TotalsByTask implements Repository.
+func (r *repository) TotalsByTask(ctx context.Context, userID uint, filter Filter) ([]TaskTotal, error) {
+	var totals []TaskTotal
+	if err := r.db.WithContext(ctx).Model(&Entry{}).
+		Scopes(filter.scope(userID)).
+		Select("time_entries.task_id, tasks.title, tasks.estimate_minutes, SUM(time_entries.duration) AS seconds").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Group("time_entries.task_id, tasks.title, tasks.estimate_minutes").
+		Order("seconds desc").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// TotalsByProject implements Repository.
+func (r *repository) TotalsByProject(ctx context.Context, userID uint, filter Filter) ([]ProjectTotal, error) {
+	var totals []ProjectTotal
+	if err := r.db.WithContext(ctx).Model(&Entry{}).
+		Scopes(filter.scope(userID)).
+		Select("tasks.project_id, COALESCE(projects.name, '') AS name, SUM(time_entries.duration) AS seconds").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Joins("LEFT JOIN projects ON projects.id = tasks.project_id").
+		Group("tasks.project_id, projects.name").
+		Order("seconds desc").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// FindExportRows implements Repository.
+// Entry diurutkan dari yang terlama agar sesuai urutan di invoice
+func (r *repository) FindExportRows(ctx context.Context, userID uint, filter Filter) ([]ExportRow, error) {
+	var rows []ExportRow
+	if err := r.db.WithContext(ctx).Model(&Entry{}).
+		Scopes(filter.scope(userID)).
+		Select("time_entries.*, tasks.title AS task_title, projects.name AS project_name").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Joins("LEFT JOIN projects ON projects.id = tasks.project_id").
+		Order("time_entries.started_at asc").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// scope menerapkan Filter ke query time_entries
+// Entry di task yang ada di trash tetap dihitung
+func (f Filter) scope(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("time_entries.user_id = ?", userID)
+		if f.TaskID != nil {
+			db = db.Where("time_entries.task_id = ?", *f.TaskID)
+		}
+		if f.ProjectID != nil {
+			db = db.Where("time_entries.task_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+				Table("tasks").Select("id").Where("project_id = ?", *f.ProjectID))
+		}
+		if f.From != nil {
+			db = db.Where("time_entries.started_at >= ?", *f.From)
+		}
+		if f.To != nil {
+			db = db.Where("time_entries.started_at < ?", *f.To)
+		}
+		if f.Stopped {
+			db = db.Where("time_entries.ended_at IS NOT NULL")
+		}
+		return db
+	}
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
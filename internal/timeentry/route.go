@@ -0,0 +1,23 @@
+package timeentry
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	entries := app.Group("/api/time-entries")
+
+	entries.Post("/", middlewares.Auth(cfg), ctrl.CreateEntry)
+	entries.Get("/", middlewares.Auth(cfg), ctrl.GetEntries)
+	// Route statis didaftarkan sebelum /:id
+	entries.Get("/timer", middlewares.Auth(cfg), ctrl.GetRunningTimer)
+	entries.Post("/timer/start", middlewares.Auth(cfg), ctrl.StartTimer)
+	entries.Post("/timer/stop", middlewares.Auth(cfg), ctrl.StopTimer)
+	entries.Get("/summary", middlewares.Auth(cfg), ctrl.GetSummary)
+	entries.Get("/export", middlewares.Auth(cfg), ctrl.ExportCSV)
+	entries.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateEntry)
+	entries.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteEntry)
+}
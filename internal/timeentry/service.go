@@ -0,0 +1,320 @@
+package timeentry
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"rest-api/internal/task"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	StartTimer(ctx context.Context, userID uint, req *StartRequest) (*Response, error)
+	StopTimer(ctx context.Context, userID uint) (*Response, error)
+	GetRunningTimer(ctx context.Context, userID uint) (*Response, error)
+	CreateEntry(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetEntries(ctx context.Context, userID uint, query *ListQuery) ([]Response, error)
+	UpdateEntry(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error)
+	DeleteEntry(ctx context.Context, userID, id uint) error
+	GetSummary(ctx context.Context, userID uint, query *ListQuery) (*SummaryResponse, error)
+	ExportCSV(ctx context.Context, userID uint, query *ListQuery, w io.Writer) error
+}
+
+type service struct {
+	repo  Repository
+	tasks task.Service
+}
+
+// StartTimer implements Service.
+// Ditolak jika user masih punya timer berjalan, hentikan dulu dengan StopTimer
+// Unique index idx_time_entries_running menolak timer kedua yang dibuat bersamaan setelah lolos pengecekan FindRunning
+func (s *service) StartTimer(ctx context.Context, userID uint, req *StartRequest) (*Response, error) {
+	if _, err := s.tasks.GetTaskByID(ctx, userID, req.TaskID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindRunning(ctx, userID); err == nil {
+		return nil, ErrTimerAlreadyRunning
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	entry := &Entry{
+		UserID:    userID,
+		TaskID:    req.TaskID,
+		StartedAt: time.Now().UTC(),
+		Note:      req.Note,
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrTimerAlreadyRunning
+		}
+		return nil, ErrEntrySaveFailed.Wrap(err)
+	}
+
+	return toResponse(entry), nil
+}
+
+// StopTimer implements Service.
+func (s *service) StopTimer(ctx context.Context, userID uint) (*Response, error) {
+	entry, err := s.repo.FindRunning(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoRunningTimer
+		}
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	entry.EndedAt = &now
+	entry.Duration = int(now.Sub(entry.StartedAt).Seconds())
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, ErrEntrySaveFailed.Wrap(err)
+	}
+
+	return toResponse(entry), nil
+}
+
+// GetRunningTimer implements Service.
+// Mengembalikan nil jika user tidak punya timer berjalan
+func (s *service) GetRunningTimer(ctx context.Context, userID uint) (*Response, error) {
+	entry, err := s.repo.FindRunning(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	return toResponse(entry), nil
+}
+
+// CreateEntry implements Service.
+func (s *service) CreateEntry(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	if _, err := s.tasks.GetTaskByID(ctx, userID, req.TaskID); err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	startedAt := time.Now().UTC().Add(-duration)
+	if req.StartedAt != "" {
+		startedAt = parseTime(req.StartedAt)
+	}
+	endedAt := startedAt.Add(duration)
+
+	entry := &Entry{
+		UserID:    userID,
+		TaskID:    req.TaskID,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Duration:  int(duration.Seconds()),
+		Note:      req.Note,
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, ErrEntrySaveFailed.Wrap(err)
+	}
+
+	return toResponse(entry), nil
+}
+
+// GetEntries implements Service.
+func (s *service) GetEntries(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	entries, err := s.repo.FindAll(ctx, userID, toFilter(query))
+	if err != nil {
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(entries))
+	for i := range entries {
+		responses[i] = *toResponse(&entries[i])
+	}
+
+	return responses, nil
+}
+
+// UpdateEntry implements Service.
+// Waktu entry tidak bisa diubah selama timer masih berjalan, note tetap bisa diubah
+func (s *service) UpdateEntry(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	entry, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StartedAt != nil || req.DurationMinutes != nil {
+		if entry.EndedAt == nil {
+			return nil, ErrEntryRunning
+		}
+		if req.StartedAt != nil {
+			entry.StartedAt = parseTime(*req.StartedAt)
+		}
+		if req.DurationMinutes != nil {
+			entry.Duration = *req.DurationMinutes * 60
+		}
+		endedAt := entry.StartedAt.Add(time.Duration(entry.Duration) * time.Second)
+		entry.EndedAt = &endedAt
+	}
+	if req.Note != nil {
+		entry.Note = *req.Note
+	}
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, ErrEntrySaveFailed.Wrap(err)
+	}
+
+	return toResponse(entry), nil
+}
+
+// DeleteEntry implements Service.
+func (s *service) DeleteEntry(ctx context.Context, userID, id uint) error {
+	entry, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, entry); err != nil {
+		return ErrEntryDeleteFailed.Wrap(err)
+	}
+
+	return nil
+}
+
+// GetSummary implements Service.
+// Timer yang masih berjalan tidak ikut dihitung
+func (s *service) GetSummary(ctx context.Context, userID uint, query *ListQuery) (*SummaryResponse, error) {
+	filter := toFilter(query)
+	filter.Stopped = true
+
+	tasks, err := s.repo.TotalsByTask(ctx, userID, filter)
+	if err != nil {
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+	projects, err := s.repo.TotalsByProject(ctx, userID, filter)
+	if err != nil {
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	summary := &SummaryResponse{Tasks: tasks, Projects: projects}
+	if summary.Tasks == nil {
+		summary.Tasks = []TaskTotal{}
+	}
+	if summary.Projects == nil {
+		summary.Projects = []ProjectTotal{}
+	}
+	for _, total := range tasks {
+		summary.TotalSeconds += total.Seconds
+	}
+
+	return summary, nil
+}
+
+// exportHeader adalah kolom CSV export, durasi ditulis dalam menit dan jam desimal untuk invoice
+var exportHeader = []string{"date", "started_at", "ended_at", "duration_minutes", "duration_hours", "task_id", "task", "project", "note"}
+
+// ExportCSV implements Service.
+// Hanya entry yang sudah berhenti yang diekspor, diurutkan dari yang terlama
+func (s *service) ExportCSV(ctx context.Context, userID uint, query *ListQuery, w io.Writer) error {
+	filter := toFilter(query)
+	filter.Stopped = true
+
+	rows, err := s.repo.FindExportRows(ctx, userID, filter)
+	if err != nil {
+		return ErrEntryFetchFailed.Wrap(err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		project := ""
+		if row.ProjectName != nil {
+			project = *row.ProjectName
+		}
+		if err := writer.Write([]string{
+			row.StartedAt.UTC().Format(time.DateOnly),
+			row.StartedAt.UTC().Format(time.RFC3339),
+			row.EndedAt.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(float64(row.Duration)/60, 'f', 2, 64),
+			strconv.FormatFloat(float64(row.Duration)/3600, 'f', 2, 64),
+			strconv.FormatUint(uint64(row.TaskID), 10),
+			csvCell(row.TaskTitle),
+			csvCell(project),
+			csvCell(row.Note),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvCell mencegah CSV injection: nilai dari user yang diawali karakter formula (=, +, -, @, tab, CR)
+// diberi prefix ' agar spreadsheet menampilkannya sebagai teks, bukan mengeksekusinya
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// findOwned mengambil entry dan memastikan entry milik userID
+func (s *service) findOwned(ctx context.Context, userID, id uint) (*Entry, error) {
+	entry, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, ErrEntryFetchFailed.Wrap(err)
+	}
+
+	if entry.UserID != userID {
+		return nil, ErrEntryForbidden
+	}
+
+	return entry, nil
+}
+
+// toFilter mengubah query menjadi Filter
+// Format waktu sudah divalidasi oleh tag validate, sehingga error parse bisa diabaikan
+func toFilter(query *ListQuery) Filter {
+	filter := Filter{TaskID: query.TaskID, ProjectID: query.ProjectID}
+	if query.From != "" {
+		from := parseTime(query.From)
+		filter.From = &from
+	}
+	if query.To != "" {
+		to := parseTime(query.To)
+		filter.To = &to
+	}
+	return filter
+}
+
+// parseTime membaca waktu RFC 3339 yang sudah divalidasi dan mengubahnya ke UTC
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t.UTC()
+}
+
+func toResponse(entry *Entry) *Response {
+	return &Response{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		DurationSeconds: entry.Duration,
+		Running:         entry.EndedAt == nil,
+		Note:            entry.Note,
+		CreatedAt:       entry.CreatedAt,
+		UpdatedAt:       entry.UpdatedAt,
+		UserID:          entry.UserID,
+	}
+}
+
+func NewService(repo Repository, tasks task.Service) Service {
+	return withTracing(&service{repo: repo, tasks: tasks})
+}
@@ -0,0 +1,159 @@
+package timeentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/database/dbtest"
+	"rest-api/internal/search"
+	"rest-api/internal/task"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// newTestService membuat Service di atas database test beserta satu user dan satu task miliknya
+func newTestService(t *testing.T) (Service, *gorm.DB, uint, uint) {
+	t.Helper()
+	db := dbtest.Open(t)
+
+	user := &auth.User{Username: "budi", Email: "budi@mail.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	tasks := task.NewService(task.NewRepository(db), &config.Config{}, auditlog.Nop(), search.NewIndex(db))
+	created, err := tasks.CreateTask(context.Background(), user.ID, &task.CreateRequest{Title: "=HYPERLINK(\"http://evil\")"})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	return NewService(NewRepository(db), tasks), db, user.ID, created.ID
+}
+
+func TestStartTimerAllowsOneRunningTimerConcurrently(t *testing.T) {
+	ctx := context.Background()
+	svc, db, userID, taskID := newTestService(t)
+
+	// Semua goroutine dimulai bersamaan agar pengecekan FindRunning saling tumpang tindih
+	const count = 10
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, count)
+	for i := range count {
+		wg.Go(func() {
+			<-start
+			_, errs[i] = svc.StartTimer(ctx, userID, &StartRequest{TaskID: taskID})
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	started := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			started++
+		case !errors.Is(err, ErrTimerAlreadyRunning):
+			t.Errorf("StartTimer() error = %v, want nil or %v", err, ErrTimerAlreadyRunning)
+		}
+	}
+	if started != 1 {
+		t.Errorf("StartTimer() succeeded %d times, want 1", started)
+	}
+
+	var running int64
+	if err := db.Model(&Entry{}).Where("user_id = ? AND ended_at IS NULL", userID).Count(&running).Error; err != nil {
+		t.Fatalf("count running: %v", err)
+	}
+	if running != 1 {
+		t.Errorf("running timers = %d, want 1", running)
+	}
+}
+
+func TestRunningIndexRejectsSecondRunningTimer(t *testing.T) {
+	ctx := context.Background()
+	_, db, userID, taskID := newTestService(t)
+	repo := NewRepository(db)
+
+	now := time.Now().UTC()
+	if err := repo.Create(ctx, &Entry{UserID: userID, TaskID: taskID, StartedAt: now}); err != nil {
+		t.Fatalf("Create() running error = %v", err)
+	}
+	if err := repo.Create(ctx, &Entry{UserID: userID, TaskID: taskID, StartedAt: now}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("Create() second running error = %v, want %v", err, gorm.ErrDuplicatedKey)
+	}
+
+	// Entry yang sudah berhenti tidak dibatasi index
+	ended := now.Add(time.Hour)
+	for range 2 {
+		if err := repo.Create(ctx, &Entry{UserID: userID, TaskID: taskID, StartedAt: now, EndedAt: &ended, Duration: 3600}); err != nil {
+			t.Fatalf("Create() stopped error = %v", err)
+		}
+	}
+}
+
+func TestExportCSVEscapesFormulas(t *testing.T) {
+	ctx := context.Background()
+	svc, _, userID, taskID := newTestService(t)
+
+	for _, note := range []string{"@SUM(A1)", "-1+2", "catatan biasa"} {
+		if _, err := svc.CreateEntry(ctx, userID, &CreateRequest{TaskID: taskID, DurationMinutes: 30, Note: note}); err != nil {
+			t.Fatalf("CreateEntry() error = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := svc.ExportCSV(ctx, userID, &ListQuery{}, &buf); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("ExportCSV() rows = %d, want header + 3", len(records))
+	}
+
+	notes := map[string]bool{}
+	for _, record := range records[1:] {
+		if got, want := record[6], "'=HYPERLINK(\"http://evil\")"; got != want {
+			t.Errorf("task cell = %q, want %q", got, want)
+		}
+		notes[record[8]] = true
+	}
+	for _, want := range []string{"'@SUM(A1)", "'-1+2", "catatan biasa"} {
+		if !notes[want] {
+			t.Errorf("note cells = %v, missing %q", notes, want)
+		}
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Laporan", "Laporan"},
+		{"a=b", "a=b"},
+		{"=1+1", "'=1+1"},
+		{"+62812", "'+62812"},
+		{"-", "'-"},
+		{"@user", "'@user"},
+		{"\tcmd", "'\tcmd"},
+		{"\rcmd", "'\rcmd"},
+	}
+
+	for _, tt := range tests {
+		if got := csvCell(tt.value); got != tt.want {
+			t.Errorf("csvCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
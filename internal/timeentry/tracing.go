@@ -0,0 +1,90 @@
+package timeentry
+
+import (
+	"context"
+	"io"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// StartTimer implements Service.
+func (t *tracedService) StartTimer(ctx context.Context, userID uint, req *StartRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.StartTimer", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(req.TaskID)))
+	res, err := t.next.StartTimer(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// StopTimer implements Service.
+func (t *tracedService) StopTimer(ctx context.Context, userID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.StopTimer", attribute.Int("user.id", int(userID)))
+	res, err := t.next.StopTimer(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetRunningTimer implements Service.
+func (t *tracedService) GetRunningTimer(ctx context.Context, userID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.GetRunningTimer", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetRunningTimer(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// CreateEntry implements Service.
+func (t *tracedService) CreateEntry(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.CreateEntry", attribute.Int("user.id", int(userID)), attribute.Int("task.id", int(req.TaskID)))
+	res, err := t.next.CreateEntry(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetEntries implements Service.
+func (t *tracedService) GetEntries(ctx context.Context, userID uint, query *ListQuery) ([]Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.GetEntries", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetEntries(ctx, userID, query)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateEntry implements Service.
+func (t *tracedService) UpdateEntry(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.UpdateEntry", attribute.Int("user.id", int(userID)), attribute.Int("time_entry.id", int(id)))
+	res, err := t.next.UpdateEntry(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteEntry implements Service.
+func (t *tracedService) DeleteEntry(ctx context.Context, userID, id uint) error {
+	ctx, span := tracing.Start(ctx, "timeentry.DeleteEntry", attribute.Int("user.id", int(userID)), attribute.Int("time_entry.id", int(id)))
+	err := t.next.DeleteEntry(ctx, userID, id)
+	tracing.End(span, err)
+	return err
+}
+
+// GetSummary implements Service.
+func (t *tracedService) GetSummary(ctx context.Context, userID uint, query *ListQuery) (*SummaryResponse, error) {
+	ctx, span := tracing.Start(ctx, "timeentry.GetSummary", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetSummary(ctx, userID, query)
+	tracing.End(span, err)
+	return res, err
+}
+
+// ExportCSV implements Service.
+func (t *tracedService) ExportCSV(ctx context.Context, userID uint, query *ListQuery, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "timeentry.ExportCSV", attribute.Int("user.id", int(userID)))
+	err := t.next.ExportCSV(ctx, userID, query, w)
+	tracing.End(span, err)
+	return err
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"rest-api/internal/auth"
 	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,17 +22,17 @@ func NewController(service Service) *Controller {
 // @Tags User
 // @Produce json
 // @Success 200 {object} response.SuccessResponse
-// @Failure 401 {object} response.ErrorResponse
+// @Failure 401 {object} response.ProblemResponse
 // @Router /api/users/profile [get]
 func (ctrl *Controller) GetProfile(c *fiber.Ctx) error {
 	user := c.Locals("user").(*auth.User)
 
-	userResponse, err := ctrl.service.GetProfile(user.ID)
+	userResponse, err := ctrl.service.GetProfile(c.UserContext(), user.ID)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Profile retrieved successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "PROFILE_RETRIEVED", fiber.Map{
 		"user": userResponse,
 	})
 }
@@ -44,7 +45,11 @@ func (ctrl *Controller) GetProfile(c *fiber.Ctx) error {
 // @Param id path int true "User ID"
 // @Param data body UpdateRequest true "User data"
 // @Success 200 {object} response.SuccessResponse
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 409 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
 // @Router /api/users/{id} [put]
 func (ctrl *Controller) UpdateUser(c *fiber.Ctx) error {
 	currentUser := c.Locals("user").(*auth.User)
@@ -52,28 +57,20 @@ func (ctrl *Controller) UpdateUser(c *fiber.Ctx) error {
 
 	targetUserID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+		return ErrInvalidUserID
 	}
 
 	var req UpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid request body")
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	userResponse, err := ctrl.service.UpdateUser(currentUser.ID, uint(targetUserID), &req)
+	userResponse, err := ctrl.service.UpdateUser(c.UserContext(), currentUser.ID, uint(targetUserID), &req)
 	if err != nil {
-		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "user not found" {
-			statusCode = fiber.StatusNotFound
-		} else if err.Error() == "unauthorized to update this user" {
-			statusCode = fiber.StatusForbidden
-		} else if err.Error() == "email already in use" || err.Error() == "username already in use" {
-			statusCode = fiber.StatusBadRequest
-		}
-		return response.Error(c, statusCode, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "Profile updated successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "PROFILE_UPDATED", fiber.Map{
 		"user": userResponse,
 	})
 }
@@ -82,20 +79,16 @@ func (ctrl *Controller) GetUserByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	userID, err := strconv.ParseUint(id, 10, 32)
-	if (err != nil) {
-		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	if err != nil {
+		return ErrInvalidUserID
 	}
 
-	userResponse, err := ctrl.service.GetUserByID(uint(userID))
+	userResponse, err := ctrl.service.GetUserByID(c.UserContext(), uint(userID))
 	if err != nil {
-		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "user not found" {
-			statusCode = fiber.StatusNotFound
-		}
-		return response.Error(c, statusCode, err.Error())
+		return err
 	}
 
-	return response.Success(c, fiber.StatusOK, "User retrieved successfully", fiber.Map{
+	return response.Success(c, fiber.StatusOK, "USER_RETRIEVED", fiber.Map{
 		"user": userResponse,
 	})
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+package user
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul user
+var (
+	ErrUserNotFound       = apperror.NotFound("USER_NOT_FOUND", "user not found")
+	ErrUserForbidden      = apperror.Forbidden("USER_FORBIDDEN", "unauthorized to update this user")
+	ErrInvalidUserID      = apperror.BadRequest("INVALID_USER_ID", "Invalid user ID")
+	ErrInvalidRole        = apperror.BadRequest("INVALID_ROLE", "role must be user or admin")
+	ErrEmailInUse         = apperror.Conflict("EMAIL_IN_USE", "email already in use")
+	ErrUsernameInUse      = apperror.Conflict("USERNAME_IN_USE", "username already in use")
+	ErrUserFetchFailed    = apperror.Internal("USER_FETCH_FAILED", "failed to retrieve user", nil)
+	ErrUserUpdateFailed   = apperror.Internal("USER_UPDATE_FAILED", "failed to update user", nil)
+	ErrPasswordHashFailed = apperror.Internal("PASSWORD_HASH_FAILED", "failed to hash password", nil)
+)
@@ -2,27 +2,44 @@ package user
 
 import "time"
 
+// Role user, sama dengan auth.RoleUser dan auth.RoleAdmin
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Username        string     `json:"username" gorm:"unique;not null"`
+	Email           string     `json:"email" gorm:"unique;not null"`
+	Password        string     `json:"-" gorm:"not null"`
+	Locale          string     `json:"locale" gorm:"size:10"` // Preferensi bahasa (kosong = ikuti Accept-Language)
+	Role            string     `json:"role" gorm:"size:20;not null;default:user"`
+	DisabledAt      *time.Time `json:"disabledAt"`
+	AutoArchiveDays int        `json:"autoArchiveDays" gorm:"not null;default:0"` // Arsipkan task yang selesai lebih dari N hari (0 = nonaktif)
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // Request DTOs
+// Field pointer bernilai nil jika tidak dikirim, omitnil melewati validasi untuk field tersebut
 type UpdateRequest struct {
-	Username *string `json:"username"`
-	Email    *string `json:"email"`
-	Password *string `json:"password"`
+	Username        *string `json:"username" validate:"omitnil,min=3"`
+	Email           *string `json:"email" validate:"omitnil,email"`
+	Password        *string `json:"password" validate:"omitnil,min=6"`
+	Locale          *string `json:"locale" validate:"omitnil,oneof=en id"`
+	AutoArchiveDays *int    `json:"autoArchiveDays" validate:"omitnil,min=0,max=3650"`
 }
 
 // Response DTOs
 type Response struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-}
\ No newline at end of file
+	ID              uint       `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Locale          string     `json:"locale"`
+	Role            string     `json:"role"`
+	DisabledAt      *time.Time `json:"disabledAt,omitempty"`
+	AutoArchiveDays int        `json:"autoArchiveDays"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
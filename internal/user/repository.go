@@ -1,70 +1,74 @@
 package user
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	FindByID(id uint) (*User, error)
-	FindByEmail(email string) (*User, error)
-	FindByUsername(username string) (*User, error)
-	Update(user *User) error
-	ExistsByEmail(email string) (bool, error)
-	ExistsByUsername(username string) (bool, error)
+	FindByID(ctx context.Context, id uint) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	Update(ctx context.Context, user *User) error
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	ExistsByUsername(ctx context.Context, username string) (bool, error)
 }
 
+// Perbandingan email/username memakai LOWER() agar hasilnya sama di semua database
+// (collation default MySQL case-insensitive, PostgreSQL dan SQLite case-sensitive)
 type repository struct {
 	db *gorm.DB
 }
 
 // ExistsByEmail implements Repository.
-func (r *repository) ExistsByEmail(email string) (bool, error) {
+func (r *repository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&User{}).Where("LOWER(email) = LOWER(?)", email).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
 // ExistsByUsername implements Repository.
-func (r *repository) ExistsByUsername(username string) (bool, error) {
+func (r *repository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&User{}).Where("LOWER(username) = LOWER(?)", username).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
 // FindByEmail implements Repository.
-func (r *repository) FindByEmail(email string) (*User, error) {
+func (r *repository) FindByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // FindByID implements Repository.
-func (r *repository) FindByID(id uint) (*User, error) {
+func (r *repository) FindByID(ctx context.Context, id uint) (*User, error) {
 	var user User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // FindByUsername implements Repository.
-func (r *repository) FindByUsername(username string) (*User, error) {
+func (r *repository) FindByUsername(ctx context.Context, username string) (*User, error) {
 	var user User
-	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("LOWER(username) = LOWER(?)", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // Update implements Repository.
-func (r *repository) Update(user *User) error {
-	return r.db.Save(user).Error
+func (r *repository) Update(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Save(user).Error
 }
 
 func NewRepository(db *gorm.DB) Repository {
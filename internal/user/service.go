@@ -1,128 +1,243 @@
 package user
 
 import (
+	"context"
 	"errors"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/security"
+	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetUserByID(id uint) (*Response, error)
-	UpdateUser(currentUserID, targetUserID uint, req *UpdateRequest) (*Response, error)
-	GetProfile(userID uint) (*Response, error)
+	GetUserByID(ctx context.Context, id uint) (*Response, error)
+	UpdateUser(ctx context.Context, currentUserID, targetUserID uint, req *UpdateRequest) (*Response, error)
+	GetProfile(ctx context.Context, userID uint) (*Response, error)
+
+	// Operasi admin (dipakai oleh cmd/taskctl)
+	GetUserByEmail(ctx context.Context, email string) (*Response, error)
+	ResetPassword(ctx context.Context, userID uint, password string) error
+	SetRole(ctx context.Context, userID uint, role string) error
+	SetDisabled(ctx context.Context, userID uint, disabled bool) error
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	hasher security.PasswordHasher
+	audit  auditlog.Recorder
 }
 
 // GetProfile implements Service.
-func (s *service) GetProfile(userID uint) (*Response, error) {
-	user, err := s.repo.FindByID(userID)
+func (s *service) GetProfile(ctx context.Context, userID uint) (*Response, error) {
+	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
-		return nil, errors.New("failed to get profile")
+		return nil, ErrUserFetchFailed.Wrap(err)
 	}
 
-	response := &Response{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
-
-	return response, nil
+	return toResponse(user), nil
 }
 
 // GetUserByID implements Service.
-func (s *service) GetUserByID(id uint) (*Response, error) {
-	user, err := s.repo.FindByID(id)
+func (s *service) GetUserByID(ctx context.Context, id uint) (*Response, error) {
+	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
-		return nil, errors.New("failed to retrieve user")
-	}
-
-	response := &Response{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		return nil, ErrUserFetchFailed.Wrap(err)
 	}
 
-	return response, nil
+	return toResponse(user), nil
 }
 
 // UpdateUser implements Service.
-func (s *service) UpdateUser(currentUserID, targetUserID uint, req *UpdateRequest) (*Response, error) {
+func (s *service) UpdateUser(ctx context.Context, currentUserID, targetUserID uint, req *UpdateRequest) (*Response, error) {
 	// Check authorization
 	if currentUserID != targetUserID {
-		return nil, errors.New("unauthorized to update this user")
+		return nil, ErrUserForbidden
 	}
 
-	user, err := s.repo.FindByID(targetUserID)
+	user, err := s.repo.FindByID(ctx, targetUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
-		return nil, errors.New("failed to retrieve user")
+		return nil, ErrUserFetchFailed.Wrap(err)
 	}
+	before := snapshot(user)
 
 	// Update email if provided
 	if req.Email != nil && *req.Email != user.Email {
-		exists, err := s.repo.ExistsByEmail(*req.Email)
+		exists, err := s.repo.ExistsByEmail(ctx, *req.Email)
 		if err != nil {
-			return nil, errors.New("failed to check email availability")
+			return nil, ErrUserFetchFailed.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("email already in use")
+			return nil, ErrEmailInUse
 		}
 		user.Email = *req.Email
 	}
 
 	// Update username if provided
 	if req.Username != nil && *req.Username != user.Username {
-		exists, err := s.repo.ExistsByUsername(*req.Username)
+		exists, err := s.repo.ExistsByUsername(ctx, *req.Username)
 		if err != nil {
-			return nil, errors.New("failed to check username availability")
+			return nil, ErrUserFetchFailed.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("username already in use")
+			return nil, ErrUsernameInUse
 		}
 		user.Username = *req.Username
 	}
 
 	// Update password if provided
 	if req.Password != nil {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*req.Password), 10)
+		hashedPassword, err := s.hasher.Hash(*req.Password)
 		if err != nil {
-			return nil, errors.New("failed to hash password")
+			return nil, ErrPasswordHashFailed.Wrap(err)
 		}
-		user.Password = string(hashedPassword)
+		user.Password = hashedPassword
+	}
+
+	// Update preferensi bahasa if provided
+	if req.Locale != nil {
+		user.Locale = *req.Locale
+	}
+
+	// Update preferensi auto-archive task if provided
+	if req.AutoArchiveDays != nil {
+		user.AutoArchiveDays = *req.AutoArchiveDays
 	}
 
 	// Save changes
-	if err := s.repo.Update(user); err != nil {
-		return nil, errors.New("failed to update user")
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, ErrUserUpdateFailed.Wrap(err)
 	}
+	s.recordChanges(ctx, auditlog.ActionUserUpdate, user, before)
+
+	return toResponse(user), nil
+}
 
-	response := &Response{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+// GetUserByEmail implements Service.
+func (s *service) GetUserByEmail(ctx context.Context, email string) (*Response, error) {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, ErrUserFetchFailed.Wrap(err)
 	}
 
-	return response, nil
+	return toResponse(user), nil
+}
+
+// ResetPassword implements Service.
+func (s *service) ResetPassword(ctx context.Context, userID uint, password string) error {
+	return s.modify(ctx, userID, auditlog.ActionPasswordReset, func(user *User) error {
+		hashedPassword, err := s.hasher.Hash(password)
+		if err != nil {
+			return ErrPasswordHashFailed.Wrap(err)
+		}
+		user.Password = hashedPassword
+		return nil
+	})
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
-}
\ No newline at end of file
+// SetRole implements Service.
+func (s *service) SetRole(ctx context.Context, userID uint, role string) error {
+	if role != RoleUser && role != RoleAdmin {
+		return ErrInvalidRole
+	}
+	return s.modify(ctx, userID, auditlog.ActionRoleChange, func(user *User) error {
+		user.Role = role
+		return nil
+	})
+}
+
+// SetDisabled implements Service.
+func (s *service) SetDisabled(ctx context.Context, userID uint, disabled bool) error {
+	action := auditlog.ActionUserEnable
+	if disabled {
+		action = auditlog.ActionUserDisable
+	}
+	return s.modify(ctx, userID, action, func(user *User) error {
+		if !disabled {
+			user.DisabledAt = nil
+		} else if user.DisabledAt == nil {
+			now := time.Now().UTC()
+			user.DisabledAt = &now
+		}
+		return nil
+	})
+}
+
+// modify mengambil user, menjalankan fn untuk mengubahnya, lalu menyimpannya dan mencatat audit dengan action
+func (s *service) modify(ctx context.Context, userID uint, action string, fn func(user *User) error) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return ErrUserFetchFailed.Wrap(err)
+	}
+
+	before := snapshot(user)
+	if err := fn(user); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return ErrUserUpdateFailed.Wrap(err)
+	}
+	s.recordChanges(ctx, action, user, before)
+	return nil
+}
+
+// recordChanges mencatat perubahan field user ke audit log, tidak mencatat apa pun jika tidak ada yang berubah
+// Nilai password (hash) tidak pernah dicatat, hanya ditandai berubah
+func (s *service) recordChanges(ctx context.Context, action string, user *User, before map[string]interface{}) {
+	changes := auditlog.Diff(before, snapshot(user), "password")
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := auditlog.Target(action, auditlog.TargetUser, user.ID)
+	entry.Changes = changes
+	s.audit.Record(ctx, entry)
+}
+
+// snapshot mengambil field user yang dicatat di audit log
+func snapshot(user *User) map[string]interface{} {
+	return map[string]interface{}{
+		"username":        user.Username,
+		"email":           user.Email,
+		"password":        user.Password,
+		"locale":          user.Locale,
+		"role":            user.Role,
+		"disabled":        user.DisabledAt != nil,
+		"autoArchiveDays": user.AutoArchiveDays,
+	}
+}
+
+// toResponse mengubah model User menjadi response DTO
+func toResponse(user *User) *Response {
+	return &Response{
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Locale:          user.Locale,
+		Role:            user.Role,
+		DisabledAt:      user.DisabledAt,
+		AutoArchiveDays: user.AutoArchiveDays,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
+
+func NewService(repo Repository, hasher security.PasswordHasher, audit auditlog.Recorder) Service {
+	return withTracing(&service{repo: repo, hasher: hasher, audit: audit})
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// GetUserByID implements Service.
+func (t *tracedService) GetUserByID(ctx context.Context, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUserByID", attribute.Int("user.id", int(id)))
+	res, err := t.next.GetUserByID(ctx, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateUser implements Service.
+func (t *tracedService) UpdateUser(ctx context.Context, currentUserID, targetUserID uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "user.UpdateUser", attribute.Int("user.id", int(targetUserID)))
+	res, err := t.next.UpdateUser(ctx, currentUserID, targetUserID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetProfile implements Service.
+func (t *tracedService) GetProfile(ctx context.Context, userID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "user.GetProfile", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetProfile(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetUserByEmail implements Service.
+func (t *tracedService) GetUserByEmail(ctx context.Context, email string) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUserByEmail")
+	res, err := t.next.GetUserByEmail(ctx, email)
+	tracing.End(span, err)
+	return res, err
+}
+
+// ResetPassword implements Service.
+func (t *tracedService) ResetPassword(ctx context.Context, userID uint, password string) error {
+	ctx, span := tracing.Start(ctx, "user.ResetPassword", attribute.Int("user.id", int(userID)))
+	err := t.next.ResetPassword(ctx, userID, password)
+	tracing.End(span, err)
+	return err
+}
+
+// SetRole implements Service.
+func (t *tracedService) SetRole(ctx context.Context, userID uint, role string) error {
+	ctx, span := tracing.Start(ctx, "user.SetRole", attribute.Int("user.id", int(userID)), attribute.String("user.role", role))
+	err := t.next.SetRole(ctx, userID, role)
+	tracing.End(span, err)
+	return err
+}
+
+// SetDisabled implements Service.
+func (t *tracedService) SetDisabled(ctx context.Context, userID uint, disabled bool) error {
+	ctx, span := tracing.Start(ctx, "user.SetDisabled", attribute.Int("user.id", int(userID)), attribute.Bool("user.disabled", disabled))
+	err := t.next.SetDisabled(ctx, userID, disabled)
+	tracing.End(span, err)
+	return err
+}
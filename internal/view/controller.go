@@ -0,0 +1,247 @@
+package view
+
+import (
+	"rest-api/internal/auth"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// @Summary Create saved view
+// @Description Simpan filter list task dengan nama. Filter memakai parameter yang sama dengan GET /api/tasks (projectId, tag, priority, completed, q, due, dueFrom, dueTo, tz, fields, sort)
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Param data body CreateRequest true "View"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/views [post]
+func (ctrl *Controller) CreateView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var req CreateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	view, err := ctrl.service.CreateView(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusCreated, "VIEW_CREATED", fiber.Map{
+		"view": view,
+	})
+}
+
+// @Summary List views
+// @Description View milik user, view yang dibagikan ke user (shared=true) dan smart list bawaan (today, next-7-days, overdue, no-due-date)
+// @Tags Views
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=ListResponse}
+// @Failure 401 {object} response.ProblemResponse
+// @Router /api/views [get]
+func (ctrl *Controller) GetViews(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	views, err := ctrl.service.GetViews(c.UserContext(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEWS_RETRIEVED", views)
+}
+
+// @Summary Get view detail
+// @Description Detail view milik user atau yang dibagikan ke user
+// @Tags Views
+// @Produce json
+// @Param id path int true "View ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/views/{id} [get]
+func (ctrl *Controller) GetView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	viewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidViewID
+	}
+
+	view, err := ctrl.service.GetView(c.UserContext(), user.ID, uint(viewID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEW_RETRIEVED", fiber.Map{
+		"view": view,
+	})
+}
+
+// @Summary Update view
+// @Description Ubah nama atau ganti seluruh filter view, hanya untuk pemilik view
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Param id path int true "View ID"
+// @Param data body UpdateRequest true "View"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/views/{id} [put]
+func (ctrl *Controller) UpdateView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	viewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidViewID
+	}
+
+	var req UpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	view, err := ctrl.service.UpdateView(c.UserContext(), user.ID, uint(viewID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEW_UPDATED", fiber.Map{
+		"view": view,
+	})
+}
+
+// @Summary Delete view
+// @Description Hapus view beserta share-nya, hanya untuk pemilik view
+// @Tags Views
+// @Produce json
+// @Param id path int true "View ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/views/{id} [delete]
+func (ctrl *Controller) DeleteView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	viewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidViewID
+	}
+
+	if err := ctrl.service.DeleteView(c.UserContext(), user.ID, uint(viewID)); err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEW_DELETED", fiber.Map{})
+}
+
+// @Summary Get view tasks
+// @Description Evaluasi view tersimpan (ID) atau smart list (key: today, next-7-days, overdue, no-due-date). View yang dibagikan menampilkan task milik pemilik view
+// @Tags Views
+// @Produce json
+// @Param id path string true "View ID atau key smart list"
+// @Param query query TasksQuery false "Timezone"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/views/{id}/tasks [get]
+func (ctrl *Controller) GetViewTasks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	var query TasksQuery
+	if err := validation.ParseQuery(c, &query); err != nil {
+		return err
+	}
+
+	tasks, err := ctrl.service.GetViewTasks(c.UserContext(), user.ID, c.Params("id"), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "TASKS_RETRIEVED", fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+// @Summary Share view
+// @Description Bagikan view ke user lain lewat email, penerima bisa melihat view dan task hasilnya tanpa bisa mengubah
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Param id path int true "View ID"
+// @Param data body ShareRequest true "Penerima"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Failure 422 {object} response.ProblemResponse
+// @Router /api/views/{id}/shares [post]
+func (ctrl *Controller) ShareView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	viewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidViewID
+	}
+
+	var req ShareRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	view, err := ctrl.service.ShareView(c.UserContext(), user.ID, uint(viewID), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEW_SHARED", fiber.Map{
+		"view": view,
+	})
+}
+
+// @Summary Unshare view
+// @Description Cabut akses user ke view
+// @Tags Views
+// @Produce json
+// @Param id path int true "View ID"
+// @Param userId path int true "User ID penerima share"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ProblemResponse
+// @Failure 403 {object} response.ProblemResponse
+// @Failure 404 {object} response.ProblemResponse
+// @Router /api/views/{id}/shares/{userId} [delete]
+func (ctrl *Controller) UnshareView(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.User)
+
+	viewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return ErrInvalidViewID
+	}
+	sharedUserID, err := strconv.ParseUint(c.Params("userId"), 10, 32)
+	if err != nil {
+		return ErrInvalidUserID
+	}
+
+	view, err := ctrl.service.UnshareView(c.UserContext(), user.ID, uint(viewID), uint(sharedUserID))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.StatusOK, "VIEW_UNSHARED", fiber.Map{
+		"view": view,
+	})
+}
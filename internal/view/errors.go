@@ -0,0 +1,17 @@
+package view
+
+import "rest-api/pkg/apperror"
+
+// Domain errors untuk modul view
+var (
+	ErrViewNotFound     = apperror.NotFound("VIEW_NOT_FOUND", "view not found")
+	ErrViewForbidden    = apperror.Forbidden("VIEW_FORBIDDEN", "unauthorized to access this view")
+	ErrInvalidViewID    = apperror.BadRequest("INVALID_VIEW_ID", "Invalid view ID")
+	ErrInvalidUserID    = apperror.BadRequest("INVALID_USER_ID", "Invalid user ID")
+	ErrShareWithSelf    = apperror.BadRequest("VIEW_SHARE_SELF", "view cannot be shared with its owner")
+	ErrShareNotFound    = apperror.NotFound("VIEW_SHARE_NOT_FOUND", "view is not shared with this user")
+	ErrViewCreateFailed = apperror.Internal("VIEW_CREATE_FAILED", "failed to create view", nil)
+	ErrViewFetchFailed  = apperror.Internal("VIEW_FETCH_FAILED", "failed to retrieve view", nil)
+	ErrViewUpdateFailed = apperror.Internal("VIEW_UPDATE_FAILED", "failed to update view", nil)
+	ErrViewDeleteFailed = apperror.Internal("VIEW_DELETE_FAILED", "failed to delete view", nil)
+)
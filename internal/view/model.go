@@ -0,0 +1,79 @@
+package view
+
+import (
+	"rest-api/internal/task"
+	"time"
+)
+
+// View adalah filter list task yang disimpan dengan nama, dievaluasi ulang setiap kali dibuka
+// User yang diberi share bisa melihat task hasil view milik pemiliknya (read-only)
+type View struct {
+	ID        uint           `gorm:"primaryKey"`
+	UserID    uint           `gorm:"not null"`
+	Name      string         `gorm:"size:100;not null"`
+	Filter    task.ListQuery `gorm:"type:text;not null;serializer:json"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Shares []Share `gorm:"foreignKey:ViewID"`
+}
+
+func (View) TableName() string {
+	return "saved_views"
+}
+
+// Share memberi UserID akses baca ke view
+type Share struct {
+	ViewID    uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (Share) TableName() string {
+	return "saved_view_shares"
+}
+
+// SmartList adalah view bawaan yang tersedia untuk semua user, dibuka lewat key
+type SmartList struct {
+	Key    string         `json:"key"`
+	Name   string         `json:"name"`
+	Filter task.ListQuery `json:"filter"`
+}
+
+// Query DTOs
+type TasksQuery struct {
+	Timezone string `json:"tz" query:"tz" validate:"omitempty,timezone"` // menimpa tz filter view, contoh Asia/Jakarta
+}
+
+// Request DTOs
+type CreateRequest struct {
+	Name   string         `json:"name" validate:"required,max=100"`
+	Filter task.ListQuery `json:"filter"`
+}
+
+// Field pointer bernilai nil jika tidak dikirim, filter diganti seluruhnya
+type UpdateRequest struct {
+	Name   *string         `json:"name" validate:"omitnil,min=1,max=100"`
+	Filter *task.ListQuery `json:"filter"`
+}
+
+type ShareRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// Response DTOs
+type Response struct {
+	ID         uint           `json:"id"`
+	Name       string         `json:"name"`
+	Filter     task.ListQuery `json:"filter"`
+	Shared     bool           `json:"shared"`               // true jika view milik user lain yang dibagikan ke user ini
+	SharedWith []uint         `json:"sharedWith,omitempty"` // ID user penerima share, hanya untuk pemilik
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	UserID     uint           `json:"userId"`
+}
+
+type ListResponse struct {
+	Views      []Response  `json:"views"`
+	SmartLists []SmartList `json:"smartLists"`
+}
@@ -0,0 +1,105 @@
+package view
+
+import (
+	"context"
+	"rest-api/internal/auth"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, view *View) error
+	Update(ctx context.Context, view *View) error
+	FindByID(ctx context.Context, id uint) (*View, error)
+	FindAccessible(ctx context.Context, userID uint) ([]View, error)
+	Delete(ctx context.Context, view *View) error
+	AddShare(ctx context.Context, share *Share) error
+	RemoveShare(ctx context.Context, viewID, userID uint) (int64, error)
+	FindUserIDByEmail(ctx context.Context, email string) (uint, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// Create implements Repository.
+func (r *repository) Create(ctx context.Context, view *View) error {
+	return r.db.WithContext(ctx).Omit("Shares").Create(view).Error
+}
+
+// Update implements Repository.
+func (r *repository) Update(ctx context.Context, view *View) error {
+	return r.db.WithContext(ctx).Omit("Shares").Save(view).Error
+}
+
+// FindByID implements Repository.
+func (r *repository) FindByID(ctx context.Context, id uint) (*View, error) {
+	var view View
+	if err := r.db.WithContext(ctx).Preload("Shares").First(&view, id).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// FindAccessible implements Repository.
+// Mengembalikan view milik user dan view user lain yang dibagikan ke user, diurutkan berdasarkan nama
+func (r *repository) FindAccessible(ctx context.Context, userID uint) ([]View, error) {
+	shared := r.db.WithContext(ctx).Model(&Share{}).Select("view_id").Where("user_id = ?", userID)
+
+	var views []View
+	if err := r.db.WithContext(ctx).
+		Preload("Shares").
+		Where("user_id = ? OR id IN (?)", userID, shared).
+		Order("name asc").
+		Order("id asc").
+		Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// Delete implements Repository.
+// Share view ikut terhapus lewat ON DELETE CASCADE
+func (r *repository) Delete(ctx context.Context, view *View) error {
+	return r.db.WithContext(ctx).Delete(view).Error
+}
+
+// AddShare implements Repository.
+// Share yang sudah ada diabaikan
+func (r *repository) AddShare(ctx context.Context, share *Share) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Share{}).
+		Where("view_id = ? AND user_id = ?", share.ViewID, share.UserID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(share).Error
+}
+
+// RemoveShare implements Repository.
+func (r *repository) RemoveShare(ctx context.Context, viewID, userID uint) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("view_id = ? AND user_id = ?", viewID, userID).
+		Delete(&Share{})
+	return result.RowsAffected, result.Error
+}
+
+// FindUserIDByEmail implements Repository.
+// Mengembalikan gorm.ErrRecordNotFound jika tidak ada user aktif dengan email tersebut
+func (r *repository) FindUserIDByEmail(ctx context.Context, email string) (uint, error) {
+	var user auth.User
+	if err := r.db.WithContext(ctx).
+		Select("id").
+		Where("email = ? AND disabled_at IS NULL", email).
+		First(&user).Error; err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
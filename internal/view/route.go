@@ -0,0 +1,21 @@
+package view
+
+import (
+	"rest-api/pkg/config"
+	"rest-api/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App, cfg *config.Config, ctrl *Controller) {
+	views := app.Group("/api/views")
+
+	views.Post("/", middlewares.Auth(cfg), ctrl.CreateView)
+	views.Get("/", middlewares.Auth(cfg), ctrl.GetViews)
+	views.Get("/:id", middlewares.Auth(cfg), ctrl.GetView)
+	views.Put("/:id", middlewares.Auth(cfg), ctrl.UpdateView)
+	views.Delete("/:id", middlewares.Auth(cfg), ctrl.DeleteView)
+	views.Get("/:id/tasks", middlewares.Auth(cfg), ctrl.GetViewTasks)
+	views.Post("/:id/shares", middlewares.Auth(cfg), ctrl.ShareView)
+	views.Delete("/:id/shares/:userId", middlewares.Auth(cfg), ctrl.UnshareView)
+}
@@ -0,0 +1,285 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"rest-api/internal/task"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	CreateView(ctx context.Context, userID uint, req *CreateRequest) (*Response, error)
+	GetViews(ctx context.Context, userID uint) (*ListResponse, error)
+	GetView(ctx context.Context, userID, id uint) (*Response, error)
+	UpdateView(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error)
+	DeleteView(ctx context.Context, userID, id uint) error
+	GetViewTasks(ctx context.Context, userID uint, id string, query *TasksQuery) ([]task.Response, error)
+	ShareView(ctx context.Context, userID, id uint, req *ShareRequest) (*Response, error)
+	UnshareView(ctx context.Context, userID, id, sharedUserID uint) (*Response, error)
+}
+
+type service struct {
+	repo  Repository
+	tasks task.Service
+}
+
+var (
+	incomplete = false
+
+	// smartLists adalah view bawaan, dibuka lewat key di GET /api/views/:id/tasks
+	smartLists = []SmartList{
+		{Key: "today", Name: "Today", Filter: task.ListQuery{Due: task.DueToday, Completed: &incomplete, Sort: task.SortDue}},
+		{Key: "next-7-days", Name: "Next 7 days", Filter: task.ListQuery{Due: task.DueNext7Days, Completed: &incomplete, Sort: task.SortDue}},
+		{Key: "overdue", Name: "Overdue", Filter: task.ListQuery{Due: task.DueOverdue, Sort: task.SortDue}},
+		{Key: "no-due-date", Name: "No due date", Filter: task.ListQuery{Due: task.DueNone, Completed: &incomplete}},
+	}
+)
+
+// CreateView implements Service.
+func (s *service) CreateView(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	if err := task.ValidateFilter(req.Filter.Filter); err != nil {
+		return nil, err
+	}
+
+	view := &View{UserID: userID, Name: strings.TrimSpace(req.Name), Filter: req.Filter}
+	if err := s.repo.Create(ctx, view); err != nil {
+		return nil, ErrViewCreateFailed.Wrap(err)
+	}
+
+	return toResponse(view, userID), nil
+}
+
+// GetViews implements Service.
+// View milik user dan view yang dibagikan ke user, ditambah smart list bawaan
+func (s *service) GetViews(ctx context.Context, userID uint) (*ListResponse, error) {
+	views, err := s.repo.FindAccessible(ctx, userID)
+	if err != nil {
+		return nil, ErrViewFetchFailed.Wrap(err)
+	}
+
+	responses := make([]Response, len(views))
+	for i := range views {
+		responses[i] = *toResponse(&views[i], userID)
+	}
+
+	return &ListResponse{Views: responses, SmartLists: smartLists}, nil
+}
+
+// GetView implements Service.
+func (s *service) GetView(ctx context.Context, userID, id uint) (*Response, error) {
+	view, err := s.findAccessible(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(view, userID), nil
+}
+
+// UpdateView implements Service.
+// Hanya pemilik view yang bisa mengubah view
+func (s *service) UpdateView(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	view, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		view.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Filter != nil {
+		if err := task.ValidateFilter(req.Filter.Filter); err != nil {
+			return nil, err
+		}
+		view.Filter = *req.Filter
+	}
+	if err := s.repo.Update(ctx, view); err != nil {
+		return nil, ErrViewUpdateFailed.Wrap(err)
+	}
+
+	return toResponse(view, userID), nil
+}
+
+// DeleteView implements Service.
+func (s *service) DeleteView(ctx context.Context, userID, id uint) error {
+	view, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, view); err != nil {
+		return ErrViewDeleteFailed.Wrap(err)
+	}
+
+	return nil
+}
+
+// GetViewTasks implements Service.
+// id berupa ID view tersimpan atau key smart list (contoh: today)
+// View yang dibagikan dievaluasi terhadap task milik pemilik view
+func (s *service) GetViewTasks(ctx context.Context, userID uint, id string, query *TasksQuery) ([]task.Response, error) {
+	var filter task.ListQuery
+	ownerID := userID
+
+	if viewID, err := strconv.ParseUint(id, 10, 32); err == nil {
+		view, err := s.findAccessible(ctx, userID, uint(viewID))
+		if err != nil {
+			return nil, err
+		}
+		filter, ownerID = view.Filter, view.UserID
+	} else {
+		list, ok := findSmartList(id)
+		if !ok {
+			return nil, ErrViewNotFound
+		}
+		filter = list.Filter
+	}
+
+	if query.Timezone != "" {
+		filter.Timezone = query.Timezone
+	}
+	return s.tasks.GetTasksByUserID(ctx, ownerID, &filter)
+}
+
+// ShareView implements Service.
+// Penerima share mendapat akses baca ke view dan task hasilnya, share ke user yang sama diabaikan
+// Email yang tidak terdaftar mendapat response yang sama seperti share yang berhasil (view tanpa perubahan)
+// agar endpoint ini tidak bisa dipakai untuk mengecek email terdaftar, lookup-nya dicatat di log
+func (s *service) ShareView(ctx context.Context, userID, id uint, req *ShareRequest) (*Response, error) {
+	view, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedUserID, err := s.repo.FindUserIDByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.WarnContext(ctx, "view share to unknown email", slog.Any("view_id", view.ID))
+			return s.reload(ctx, userID, view.ID)
+		}
+		return nil, ErrViewFetchFailed.Wrap(err)
+	}
+	if sharedUserID == userID {
+		return nil, ErrShareWithSelf
+	}
+
+	share := &Share{ViewID: view.ID, UserID: sharedUserID}
+	if err := s.repo.AddShare(ctx, share); err != nil {
+		return nil, ErrViewUpdateFailed.Wrap(err)
+	}
+
+	return s.reload(ctx, userID, view.ID)
+}
+
+// UnshareView implements Service.
+func (s *service) UnshareView(ctx context.Context, userID, id, sharedUserID uint) (*Response, error) {
+	view, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.RemoveShare(ctx, view.ID, sharedUserID)
+	if err != nil {
+		return nil, ErrViewUpdateFailed.Wrap(err)
+	}
+	if count == 0 {
+		return nil, ErrShareNotFound
+	}
+
+	return s.reload(ctx, userID, view.ID)
+}
+
+// findOwned mengambil view dan memastikan view milik userID
+func (s *service) findOwned(ctx context.Context, userID, id uint) (*View, error) {
+	view, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.UserID != userID {
+		return nil, ErrViewForbidden
+	}
+
+	return view, nil
+}
+
+// findAccessible mengambil view milik userID atau yang dibagikan ke userID
+func (s *service) findAccessible(ctx context.Context, userID, id uint) (*View, error) {
+	view, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.UserID != userID && !sharedWith(view, userID) {
+		return nil, ErrViewForbidden
+	}
+
+	return view, nil
+}
+
+func (s *service) find(ctx context.Context, id uint) (*View, error) {
+	view, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrViewNotFound
+		}
+		return nil, ErrViewFetchFailed.Wrap(err)
+	}
+	return view, nil
+}
+
+// reload mengambil ulang view beserta share-nya setelah share berubah
+func (s *service) reload(ctx context.Context, userID, id uint) (*Response, error) {
+	view, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(view, userID), nil
+}
+
+// findSmartList mencari smart list berdasarkan key
+func findSmartList(key string) (SmartList, bool) {
+	for _, list := range smartLists {
+		if list.Key == key {
+			return list, true
+		}
+	}
+	return SmartList{}, false
+}
+
+// sharedWith bernilai true jika view dibagikan ke userID
+func sharedWith(view *View, userID uint) bool {
+	for _, share := range view.Shares {
+		if share.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// toResponse mengubah view menjadi response untuk viewerID, daftar penerima share hanya untuk pemilik
+func toResponse(view *View, viewerID uint) *Response {
+	response := &Response{
+		ID:        view.ID,
+		Name:      view.Name,
+		Filter:    view.Filter,
+		Shared:    view.UserID != viewerID,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+		UserID:    view.UserID,
+	}
+	if !response.Shared {
+		response.SharedWith = make([]uint, len(view.Shares))
+		for i, share := range view.Shares {
+			response.SharedWith[i] = share.UserID
+		}
+	}
+	return response
+}
+
+func NewService(repo Repository, tasks task.Service) Service {
+	return withTracing(&service{repo: repo, tasks: tasks})
+}
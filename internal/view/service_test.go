@@ -0,0 +1,52 @@
+package view
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"rest-api/internal/auth"
+	"rest-api/internal/database/dbtest"
+	"rest-api/internal/search"
+	"rest-api/internal/task"
+	"rest-api/pkg/auditlog"
+	"rest-api/pkg/config"
+)
+
+func TestShareViewDoesNotRevealUnknownEmail(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	users := []*auth.User{
+		{Username: "budi", Email: "budi@mail.com", Password: "x"},
+		{Username: "ani", Email: "ani@mail.com", Password: "x"},
+	}
+	for _, user := range users {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	owner, recipient := users[0].ID, users[1].ID
+
+	tasks := task.NewService(task.NewRepository(db), &config.Config{}, auditlog.Nop(), search.NewIndex(db))
+	svc := NewService(NewRepository(db), tasks)
+	view, err := svc.CreateView(ctx, owner, &CreateRequest{Name: "Kantor"})
+	if err != nil {
+		t.Fatalf("CreateView() error = %v", err)
+	}
+
+	unknown, err := svc.ShareView(ctx, owner, view.ID, &ShareRequest{Email: "tidak-ada@mail.com"})
+	if err != nil {
+		t.Fatalf("ShareView() unknown email error = %v, want nil", err)
+	}
+	if len(unknown.SharedWith) != 0 {
+		t.Errorf("ShareView() unknown email sharedWith = %v, want empty", unknown.SharedWith)
+	}
+
+	shared, err := svc.ShareView(ctx, owner, view.ID, &ShareRequest{Email: " ani@mail.com "})
+	if err != nil {
+		t.Fatalf("ShareView() error = %v", err)
+	}
+	if !slices.Equal(shared.SharedWith, []uint{recipient}) {
+		t.Errorf("ShareView() sharedWith = %v, want [%d]", shared.SharedWith, recipient)
+	}
+}
@@ -0,0 +1,82 @@
+package view
+
+import (
+	"context"
+	"rest-api/internal/task"
+	"rest-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService membungkus Service agar setiap method menjadi child span dari request
+type tracedService struct {
+	next Service
+}
+
+func withTracing(next Service) Service {
+	return &tracedService{next: next}
+}
+
+// CreateView implements Service.
+func (t *tracedService) CreateView(ctx context.Context, userID uint, req *CreateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "view.CreateView", attribute.Int("user.id", int(userID)))
+	res, err := t.next.CreateView(ctx, userID, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetViews implements Service.
+func (t *tracedService) GetViews(ctx context.Context, userID uint) (*ListResponse, error) {
+	ctx, span := tracing.Start(ctx, "view.GetViews", attribute.Int("user.id", int(userID)))
+	res, err := t.next.GetViews(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetView implements Service.
+func (t *tracedService) GetView(ctx context.Context, userID, id uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "view.GetView", attribute.Int("user.id", int(userID)), attribute.Int("view.id", int(id)))
+	res, err := t.next.GetView(ctx, userID, id)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateView implements Service.
+func (t *tracedService) UpdateView(ctx context.Context, userID, id uint, req *UpdateRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "view.UpdateView", attribute.Int("user.id", int(userID)), attribute.Int("view.id", int(id)))
+	res, err := t.next.UpdateView(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// DeleteView implements Service.
+func (t *tracedService) DeleteView(ctx context.Context, userID, id uint) error {
+	ctx, span := tracing.Start(ctx, "view.DeleteView", attribute.Int("user.id", int(userID)), attribute.Int("view.id", int(id)))
+	err := t.next.DeleteView(ctx, userID, id)
+	tracing.End(span, err)
+	return err
+}
+
+// GetViewTasks implements Service.
+func (t *tracedService) GetViewTasks(ctx context.Context, userID uint, id string, query *TasksQuery) ([]task.Response, error) {
+	ctx, span := tracing.Start(ctx, "view.GetViewTasks", attribute.Int("user.id", int(userID)), attribute.String("view.id", id))
+	res, err := t.next.GetViewTasks(ctx, userID, id, query)
+	tracing.End(span, err)
+	return res, err
+}
+
+// ShareView implements Service.
+func (t *tracedService) ShareView(ctx context.Context, userID, id uint, req *ShareRequest) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "view.ShareView", attribute.Int("user.id", int(userID)), attribute.Int("view.id", int(id)))
+	res, err := t.next.ShareView(ctx, userID, id, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UnshareView implements Service.
+func (t *tracedService) UnshareView(ctx context.Context, userID, id, sharedUserID uint) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "view.UnshareView", attribute.Int("user.id", int(userID)), attribute.Int("view.id", int(id)), attribute.Int("shared_user.id", int(sharedUserID)))
+	res, err := t.next.UnshareView(ctx, userID, id, sharedUserID)
+	tracing.End(span, err)
+	return res, err
+}
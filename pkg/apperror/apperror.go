@@ -0,0 +1,137 @@
+// Package apperror berisi error domain bertipe yang dipakai bersama oleh service
+// Setiap error membawa kategori (NotFound, Forbidden, dll) dan kode machine-readable
+// yang dipetakan ke HTTP status secara terpusat oleh middlewares.ErrorHandler
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel error untuk setiap kategori
+// Gunakan errors.Is(err, apperror.ErrNotFound) untuk mengecek kategori error
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrInternal     = errors.New("internal error")
+)
+
+// Error adalah error domain yang dikembalikan oleh service
+type Error struct {
+	Kind    error             // Kategori error (salah satu sentinel di atas)
+	Code    string            // Kode machine-readable yang stabil (contoh: TASK_NOT_FOUND)
+	Message string            // Pesan untuk client
+	Details interface{}       // Detail tambahan untuk client (contoh: error validasi per field)
+	Params  map[string]string // Nilai placeholder untuk pesan terjemahan (contoh: {path})
+	Err     error             // Penyebab asli, tidak pernah dikirim ke client
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap mengembalikan kategori dan penyebab asli agar errors.Is/errors.As bekerja untuk keduanya
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// Is bernilai true jika target adalah *Error dengan kode yang sama
+// Sehingga errors.Is(err, task.ErrTaskNotFound) tetap bekerja walaupun error sudah di-wrap
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap mengembalikan salinan error dengan penyebab asli
+func (e *Error) Wrap(err error) *Error {
+	clone := *e
+	clone.Err = err
+	return &clone
+}
+
+// WithDetails mengembalikan salinan error dengan detail tambahan
+func (e *Error) WithDetails(details interface{}) *Error {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// WithParams mengembalikan salinan error dengan nilai placeholder untuk pesan terjemahan
+func (e *Error) WithParams(params map[string]string) *Error {
+	clone := *e
+	clone.Params = params
+	return &clone
+}
+
+func newError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// BadRequest membuat error untuk request yang tidak bisa diproses (400)
+func BadRequest(code, message string) *Error { return newError(ErrBadRequest, code, message) }
+
+// Unauthorized membuat error untuk request tanpa autentikasi yang valid (401)
+func Unauthorized(code, message string) *Error { return newError(ErrUnauthorized, code, message) }
+
+// Forbidden membuat error untuk akses ke resource milik user lain (403)
+func Forbidden(code, message string) *Error { return newError(ErrForbidden, code, message) }
+
+// NotFound membuat error untuk resource yang tidak ditemukan (404)
+func NotFound(code, message string) *Error { return newError(ErrNotFound, code, message) }
+
+// Conflict membuat error untuk konflik dengan state resource (409)
+func Conflict(code, message string) *Error { return newError(ErrConflict, code, message) }
+
+// Validation membuat error untuk input yang tidak valid (422)
+func Validation(code, message string, details interface{}) *Error {
+	e := newError(ErrValidation, code, message)
+	e.Details = details
+	return e
+}
+
+// Internal membuat error untuk kegagalan internal (500), err adalah penyebab asli
+func Internal(code, message string, err error) *Error {
+	e := newError(ErrInternal, code, message)
+	e.Err = err
+	return e
+}
+
+// Status memetakan kategori error ke HTTP status code
+func Status(err error) int {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Code mengembalikan kode error machine-readable, string kosong jika err bukan *Error
+func Code(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+// Package auditlog berisi kontrak audit log yang dipakai oleh service (auth, user, task)
+// Penyimpanan dan endpoint ada di internal/audit; package ini sengaja tidak bergantung pada modul
+// manapun agar service bisa mencatat audit tanpa import cycle
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"rest-api/pkg/logger"
+)
+
+// Action yang dicatat di audit log
+const (
+	ActionLogin             = "auth.login"
+	ActionLoginFailed       = "auth.login_failed"
+	ActionRegister          = "auth.register"
+	ActionUserUpdate        = "user.update"
+	ActionPasswordReset     = "user.password_reset"
+	ActionRoleChange        = "user.role_change"
+	ActionUserDisable       = "user.disable"
+	ActionUserEnable        = "user.enable"
+	ActionTaskCreate        = "task.create"
+	ActionTaskUpdate        = "task.update"
+	ActionTaskDelete        = "task.delete"
+	ActionTaskRestore       = "task.restore"
+	ActionTaskPurge         = "task.purge"
+	ActionTaskArchive       = "task.archive"
+	ActionTaskUnarchive     = "task.unarchive"
+	ActionTaskMove          = "task.move"
+	ActionProjectCreate     = "project.create"
+	ActionProjectUpdate     = "project.update"
+	ActionProjectDelete     = "project.delete"
+	ActionBoardCreate       = "board.create"
+	ActionBoardUpdate       = "board.update"
+	ActionBoardDelete       = "board.delete"
+	ActionCustomFieldCreate = "custom_field.create"
+	ActionCustomFieldUpdate = "custom_field.update"
+	ActionCustomFieldDelete = "custom_field.delete"
+)
+
+// Target type yang dicatat di audit log
+const (
+	TargetUser        = "user"
+	TargetTask        = "task"
+	TargetProject     = "project"
+	TargetBoard       = "board"
+	TargetCustomField = "custom_field"
+)
+
+// Redacted menggantikan nilai field sensitif (contoh: password) di Changes
+const Redacted = "[REDACTED]"
+
+// Change adalah perubahan satu field
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Changes adalah perubahan per field: {"title": {"from": "A", "to": "B"}}
+type Changes map[string]Change
+
+// Entry adalah satu event audit
+// ActorID nil berarti actor diambil dari user yang login di ctx (jika ada)
+type Entry struct {
+	ActorID    *uint
+	Action     string
+	TargetType string
+	TargetID   string
+	Changes    Changes
+	Metadata   map[string]interface{}
+}
+
+// Target membuat Entry untuk target dengan ID numerik
+func Target(action, targetType string, targetID uint) Entry {
+	return Entry{Action: action, TargetType: targetType, TargetID: fmt.Sprint(targetID)}
+}
+
+// Recorder menyimpan audit event
+// Record tidak mengembalikan error: kegagalan audit dicatat di log dan tidak membatalkan operasi bisnis
+type Recorder interface {
+	Record(ctx context.Context, entry Entry)
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(context.Context, Entry) {}
+
+// Nop adalah Recorder yang tidak mencatat apa pun (contoh: untuk test)
+func Nop() Recorder {
+	return nopRecorder{}
+}
+
+// Diff membandingkan dua snapshot field dan mengembalikan field yang berubah
+// before nil berarti resource baru dibuat, after nil berarti resource dihapus
+// Field yang ada di sensitive hanya ditandai berubah, nilainya diganti Redacted
+func Diff(before, after map[string]interface{}, sensitive ...string) Changes {
+	changes := Changes{}
+	for key, to := range after {
+		from := before[key]
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		changes[key] = Change{From: from, To: to}
+	}
+	for key, from := range before {
+		if _, ok := after[key]; !ok {
+			changes[key] = Change{From: from, To: nil}
+		}
+	}
+	for _, key := range sensitive {
+		if _, ok := changes[key]; ok {
+			changes[key] = Change{From: Redacted, To: Redacted}
+		}
+	}
+	return changes
+}
+
+type clientKey struct{}
+
+// Client adalah informasi client request yang ikut dicatat di setiap event
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// WithClient menyimpan IP dan user agent request di context
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext mengambil informasi client dari context
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientKey{}).(Client)
+	return client
+}
+
+// Actor mengembalikan actor entry: ActorID jika diisi, selain itu user yang login di ctx
+func Actor(ctx context.Context, entry Entry) *uint {
+	if entry.ActorID != nil {
+		return entry.ActorID
+	}
+	if userID := logger.UserID(ctx); userID != 0 {
+		return &userID
+	}
+	return nil
+}
@@ -3,7 +3,7 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/joho/godotenv"
@@ -12,17 +12,39 @@ import (
 // Config struct menyimpan semua konfigurasi aplikasi
 // Semua field adalah string karena dibaca dari environment variables
 type Config struct {
+		DBDriver   string // Database driver (mysql/postgres/sqlite, default: mysql)
 		DBHost     string // Database host (default: localhost)
-		DBPort     string // Database port (default: 5432 untuk PostgreSQL)
+		DBPort     string // Database port (default: 3306 untuk MySQL, 5432 untuk PostgreSQL)
 		DBUser     string // Database user
 		DBPassword string // Database password
 		DBName     string // Database name
-		DBSSLMode  string // Database SSL mode (disable/require/verify-ca/verify-full)
+		DBSSLMode  string // Database SSL mode untuk PostgreSQL (disable/require/verify-ca/verify-full)
+		DBPath     string // Path file database SQLite (":memory:" untuk in-memory)
+		DBAutoMigrate string // Jalankan migration saat server start (true/false)
 		JWTSecret  string // Secret key untuk signing JWT tokens
 		JWTExpires string // JWT expiration duration (contoh: 168h = 7 hari)
 		Port       string // Port untuk aplikasi web server
 		NodeEnv    string // Environment mode (development/production)
 		CorsOrigin string // Allowed CORS origin (URL frontend)
+		ShutdownTimeout string // Batas waktu graceful shutdown (contoh: 30s)
+		HealthCheckTimeout string // Batas waktu setiap dependency check di /readyz (contoh: 2s)
+		MetricsToken string // Bearer token untuk /metrics (kosong = tanpa autentikasi)
+		MetricsPort  string // Port terpisah untuk /metrics (kosong = di port aplikasi)
+		TracingExporter    string // Exporter OpenTelemetry (none/stdout/otlp)
+		TracingServiceName string // Nama service di trace (default: task-api)
+		TracingSampleRatio string // Proporsi trace yang disampling, 0-1 (default: 1)
+		LogLevel  string // Level log minimum (debug/info/warn/error)
+		LogFormat string // Format log (json/text)
+		AuditRetentionDays string // Lama penyimpanan audit log dalam hari (0 = simpan selamanya)
+		TrashRetentionDays string // Lama task disimpan di trash sebelum dihapus permanen (0 = tidak pernah di-purge)
+		DependencyBlocksCompletion string // Tolak penyelesaian task yang blocker-nya belum selesai (true/false)
+		DefaultLocale string // Bahasa default response API (en/id)
+
+		PasswordHashAlgo  string // Algoritma hash password untuk hash baru (argon2id/bcrypt)
+		Argon2Memory      string // Memory Argon2id dalam KiB (default: 65536)
+		Argon2Iterations  string // Jumlah iterasi Argon2id (default: 3)
+		Argon2Parallelism string // Parallelism Argon2id (default: 2)
+		BcryptCost        string // Cost bcrypt (default: 10)
 }
 
 // LoadConfig membaca konfigurasi dari file .env dan environment variables
@@ -33,23 +55,45 @@ func LoadConfig() *Config {
 	// Jika .env tidak ditemukan, tidak akan error, hanya warning
 	err := godotenv.Load()
 	if err != nil {
-		log.Println("Warning: Error loading .env file, using environment variables")
+		slog.Warn("Error loading .env file, using environment variables")
 	}
 
 	// Return Config struct dengan values dari getEnv()
 	// getEnv() akan mencari environment variable, jika tidak ada gunakan default value
 	return &Config{
+		DBDriver:   getEnv("DB_DRIVER", "mysql"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "postgres"),
+		DBPort:     getEnv("DB_PORT", ""),
+		DBUser:     getEnv("DB_USER", "root"),
 		DBPassword: getEnv("DB_PASSWORD", ""),
 		DBName:     getEnv("DB_NAME", "blog_db"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
+		DBPath:     getEnv("DB_PATH", "task-api.db"),
+		DBAutoMigrate: getEnv("DB_AUTO_MIGRATE", "true"),
 		JWTSecret:  getEnv("JWT_SECRET", "your_super_secret_jwt_key_blog_app_2025"),
 		JWTExpires: getEnv("JWT_EXPIRES_IN", "168h"),
 		Port:       getEnv("PORT", "5000"),
 		NodeEnv:    getEnv("NODE_ENV", "development"),
 		CorsOrigin: getEnv("CORS_ORIGIN", "http://localhost:3000"),
+		ShutdownTimeout: getEnv("SHUTDOWN_TIMEOUT", "30s"),
+		HealthCheckTimeout: getEnv("HEALTH_CHECK_TIMEOUT", "2s"),
+		MetricsToken: getEnv("METRICS_TOKEN", ""),
+		MetricsPort:  getEnv("METRICS_PORT", ""),
+		TracingExporter:    getEnv("TRACING_EXPORTER", "none"),
+		TracingServiceName: getEnv("TRACING_SERVICE_NAME", "task-api"),
+		TracingSampleRatio: getEnv("TRACING_SAMPLE_RATIO", "1"),
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+		LogFormat: getEnv("LOG_FORMAT", "json"),
+		AuditRetentionDays: getEnv("AUDIT_RETENTION_DAYS", "365"),
+		TrashRetentionDays: getEnv("TRASH_RETENTION_DAYS", "30"),
+		DependencyBlocksCompletion: getEnv("DEPENDENCY_BLOCKS_COMPLETION", "true"),
+		DefaultLocale: getEnv("DEFAULT_LOCALE", "en"),
+
+		PasswordHashAlgo:  getEnv("PASSWORD_HASH_ALGO", "argon2id"),
+		Argon2Memory:      getEnv("ARGON2_MEMORY", "65536"),
+		Argon2Iterations:  getEnv("ARGON2_ITERATIONS", "3"),
+		Argon2Parallelism: getEnv("ARGON2_PARALLELISM", "2"),
+		BcryptCost:        getEnv("BCRYPT_COST", "10"),
 	}
 }
 
@@ -0,0 +1,250 @@
+// Package filterql mem-parse bahasa filter sederhana menjadi AST, contoh:
+//
+//	status:open AND (tag:bug OR priority>=high) AND due<7d
+//
+// Grammar (keyword AND/OR/NOT tidak case-sensitive, AND boleh dihilangkan):
+//
+//	expr       = and { "OR" and }
+//	and        = unary { ["AND"] unary }
+//	unary      = "NOT" unary | primary
+//	primary    = "(" expr ")" | comparison
+//	comparison = field operator value
+//	operator   = ":" | "=" | "!=" | "<" | "<=" | ">" | ">="
+//	value      = word | "\"" string "\""
+//
+// Package ini hanya memeriksa syntax; nama field, operator yang diizinkan dan nilai divalidasi oleh
+// pemakainya (contoh: task service) yang juga menerjemahkan AST ke query database
+package filterql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Batas ukuran filter agar satu request tidak membuat query yang terlalu besar
+const (
+	MaxDepth      = 10 // kedalaman maksimal kurung dan NOT
+	MaxConditions = 30 // jumlah perbandingan maksimal
+)
+
+// Operator logika
+const (
+	And = "AND"
+	Or  = "OR"
+)
+
+// Operator perbandingan
+const (
+	OpMatch        = ":"
+	OpEqual        = "="
+	OpNotEqual     = "!="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+)
+
+// Node adalah elemen AST, Position adalah posisi karakter (dimulai dari 1) di filter asli
+type Node interface {
+	Position() int
+}
+
+// Logical menggabungkan dua ekspresi dengan And atau Or
+type Logical struct {
+	Op          string
+	Left, Right Node
+	Pos         int
+}
+
+// Not membalik hasil ekspresi
+type Not struct {
+	Expr Node
+	Pos  int
+}
+
+// Comparison membandingkan field dengan value
+// OpPos dan ValuePos dipakai untuk menunjuk error pada operator atau value
+type Comparison struct {
+	Field    string
+	Op       string
+	Value    string
+	Pos      int
+	OpPos    int
+	ValuePos int
+}
+
+// Position implements Node.
+func (n *Logical) Position() int { return n.Pos }
+
+// Position implements Node.
+func (n *Not) Position() int { return n.Pos }
+
+// Position implements Node.
+func (n *Comparison) Position() int { return n.Pos }
+
+// Error adalah error syntax atau validasi filter beserta posisi karakternya (dimulai dari 1)
+type Error struct {
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
+
+// Errorf membuat Error di posisi pos
+func Errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Position: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse mem-parse filter menjadi AST, error selalu bertipe *Error
+func Parse(input string) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, Errorf(tok.pos, "unexpected %s", tok.describe())
+	}
+	return node, nil
+}
+
+type parser struct {
+	tokens     []token
+	next       int
+	depth      int
+	conditions int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// keyword mengecek apakah token berikutnya adalah keyword (AND/OR/NOT)
+func (p *parser) keyword(name string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, name)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(Or) {
+		op := p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: Or, Left: left, Right: right, Pos: op.pos}
+	}
+	return left, nil
+}
+
+// parseAnd juga menggabungkan ekspresi yang bersebelahan tanpa keyword (AND implisit)
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind == tokenEOF || tok.kind == tokenRParen || p.keyword(Or) {
+			return left, nil
+		}
+		if p.keyword(And) {
+			p.advance()
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: And, Left: left, Right: right, Pos: tok.pos}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if !p.keyword("NOT") {
+		return p.parsePrimary()
+	}
+
+	not := p.advance()
+	if err := p.enter(not.pos); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	p.depth--
+	return &Not{Expr: expr, Pos: not.pos}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.advance()
+	switch {
+	case tok.kind == tokenLParen:
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenRParen {
+			return nil, Errorf(closing.pos, "expected ')' to close '(' at position %d, found %s", tok.pos, closing.describe())
+		}
+		p.depth--
+		return expr, nil
+	case tok.kind == tokenWord && (strings.EqualFold(tok.text, And) || strings.EqualFold(tok.text, Or)):
+		return nil, Errorf(tok.pos, "unexpected %s, expected a condition", strings.ToUpper(tok.text))
+	case tok.kind == tokenWord:
+		return p.parseComparison(tok)
+	case tok.kind == tokenEOF:
+		return nil, Errorf(tok.pos, "unexpected end of filter, expected a condition")
+	default:
+		return nil, Errorf(tok.pos, "unexpected %s, expected a field name", tok.describe())
+	}
+}
+
+func (p *parser) parseComparison(field token) (Node, error) {
+	op := p.advance()
+	if op.kind != tokenOperator {
+		return nil, Errorf(op.pos, "expected operator (:, =, !=, <, <=, >, >=) after field %q, found %s", field.text, op.describe())
+	}
+	value := p.advance()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, Errorf(value.pos, "expected value after %q, found %s", field.text+op.text, value.describe())
+	}
+
+	p.conditions++
+	if p.conditions > MaxConditions {
+		return nil, Errorf(field.pos, "filter has more than %d conditions", MaxConditions)
+	}
+	return &Comparison{Field: field.text, Op: op.text, Value: value.text, Pos: field.pos, OpPos: op.pos, ValuePos: value.pos}, nil
+}
+
+// enter menambah kedalaman kurung/NOT dan menolak filter yang terlalu dalam
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return Errorf(pos, "filter is nested more than %d levels deep", MaxDepth)
+	}
+	return nil
+}
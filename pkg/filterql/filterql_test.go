@@ -0,0 +1,140 @@
+package filterql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// render menulis AST dalam bentuk ringkas dengan kurung eksplisit agar precedence mudah dibandingkan
+func render(node Node) string {
+	switch n := node.(type) {
+	case *Logical:
+		return "(" + render(n.Left) + " " + n.Op + " " + render(n.Right) + ")"
+	case *Not:
+		return "NOT " + render(n.Expr)
+	case *Comparison:
+		return n.Field + n.Op + n.Value
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"single comparison", "status:open", "status:open"},
+		{"all operators", "a:1 b=2 c!=3 d<4 e<=5 f>6 g>=7",
+			"((((((a:1 AND b=2) AND c!=3) AND d<4) AND e<=5) AND f>6) AND g>=7)"},
+		{"implicit AND", "status:open tag:bug", "(status:open AND tag:bug)"},
+		{"AND binds tighter than OR", "a:1 OR b:2 AND c:3", "(a:1 OR (b:2 AND c:3))"},
+		{"implicit AND binds tighter than OR", "a:1 b:2 OR c:3", "((a:1 AND b:2) OR c:3)"},
+		{"OR is left associative", "a:1 OR b:2 OR c:3", "((a:1 OR b:2) OR c:3)"},
+		{"parentheses override precedence", "a:1 AND (b:2 OR c:3)", "(a:1 AND (b:2 OR c:3))"},
+		{"NOT binds tighter than AND", "NOT a:1 AND b:2", "(NOT a:1 AND b:2)"},
+		{"NOT of group", "NOT (a:1 OR b:2)", "NOT (a:1 OR b:2)"},
+		{"double NOT", "NOT NOT a:1", "NOT NOT a:1"},
+		{"keywords are case-insensitive", "a:1 or not b:2 and c:3", "(a:1 OR (NOT b:2 AND c:3))"},
+		{"keyword as value", "tag:and", "tag:and"},
+		{"quoted value with spaces", `title:"rapat mingguan"`, "title:rapat mingguan"},
+		{"quoted value with escapes", `title:"kata \"kunci\" C:\\tmp"`, `title:kata "kunci" C:\tmp`},
+		{"quoted empty value", `title:""`, "title:"},
+		{"quoted value keeps operators and parentheses", `title:"a:b (c)"`, "title:a:b (c)"},
+		{"relative dates", "due<=7d created>-2w due>=+1d", "((due<=7d AND created>-2w) AND due>=+1d)"},
+		{"no whitespace around parentheses", "(a:1)OR(b:2)", "(a:1 OR b:2)"},
+		{"unicode value", "tag:café", "tag:café"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if got := render(node); got != tt.want {
+				t.Fatalf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	node, err := Parse(`judul:"café" OR  priority>=high`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	or, ok := node.(*Logical)
+	if !ok {
+		t.Fatalf("Parse() = %T, want *Logical", node)
+	}
+	if or.Pos != 14 {
+		t.Errorf("OR position = %d, want 14", or.Pos)
+	}
+	// Posisi dihitung per karakter, é dihitung satu walaupun dua byte
+	right := or.Right.(*Comparison)
+	if right.Pos != 18 || right.OpPos != 26 || right.ValuePos != 28 {
+		t.Errorf("comparison positions = %d/%d/%d, want 18/26/28", right.Pos, right.OpPos, right.ValuePos)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+		message  string
+	}{
+		{"empty", "", 1, "unexpected end of filter, expected a condition"},
+		{"whitespace only", "   ", 4, "unexpected end of filter"},
+		{"unterminated string", `title:"rapat`, 7, "unterminated string"},
+		{"lone bang", "status!open", 7, "did you mean '!='?"},
+		{"missing operator", "status open", 8, `expected operator (:, =, !=, <, <=, >, >=) after field "status"`},
+		{"missing value", "status:", 8, `expected value after "status:", found end of filter`},
+		{"operator as value", "status:=open", 8, `expected value after "status:", found '='`},
+		{"missing field", ":open", 1, "unexpected ':', expected a field name"},
+		{"unexpected closing parenthesis", "a:1)", 4, "unexpected ')'"},
+		{"unclosed parenthesis", "(a:1 b:2", 9, "expected ')' to close '(' at position 1, found end of filter"},
+		{"empty parentheses", "()", 2, "unexpected ')', expected a field name"},
+		{"leading AND", "AND a:1", 1, "unexpected AND, expected a condition"},
+		{"double OR", "a:1 or or b:2", 8, "unexpected OR, expected a condition"},
+		{"trailing OR", "a:1 OR", 7, "unexpected end of filter"},
+		{"trailing NOT", "a:1 NOT", 8, "unexpected end of filter"},
+		{"position counts characters not bytes", "tag:café )", 10, "unexpected ')'"},
+		{"too deep parentheses", strings.Repeat("(", MaxDepth+1) + "a:1" + strings.Repeat(")", MaxDepth+1),
+			MaxDepth + 1, "nested more than 10 levels deep"},
+		{"too deep NOT", strings.Repeat("NOT ", MaxDepth+1) + "a:1", MaxDepth*4 + 1, "nested more than 10 levels deep"},
+		{"too many conditions", strings.Repeat("a:1 ", MaxConditions+1), MaxConditions*4 + 1, "more than 30 conditions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var filterErr *Error
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.input, err)
+			}
+			if filterErr.Position != tt.position || !strings.Contains(filterErr.Message, tt.message) {
+				t.Fatalf("Parse(%q) error = %v, want position %d: %s", tt.input, err, tt.position, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	inputs := []string{
+		strings.Repeat("(", MaxDepth) + "a:1" + strings.Repeat(")", MaxDepth),
+		strings.Repeat("NOT ", MaxDepth) + "a:1",
+		strings.TrimSpace(strings.Repeat("a:1 ", MaxConditions)),
+		// Kedalaman dihitung per cabang, bukan total kurung
+		strings.Repeat("(a:1) ", MaxDepth+1),
+	}
+	for _, input := range inputs {
+		if _, err := Parse(input); err != nil {
+			t.Errorf("Parse(%q) error = %v, want nil", input, err)
+		}
+	}
+}
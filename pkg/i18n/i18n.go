@@ -0,0 +1,141 @@
+// Package i18n menyediakan katalog pesan API dalam beberapa bahasa
+// Pesan di-key berdasarkan kode yang stabil (contoh: TASK_NOT_FOUND, TASK_CREATED)
+// Katalog disimpan di folder locales/ dalam format JSON dan di-embed ke binary
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalsKey adalah key c.Locals untuk menyimpan locale hasil negosiasi
+const LocalsKey = "locale"
+
+// DefaultLocale dipakai jika tidak ada locale yang cocok dengan permintaan client
+var DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs berisi pesan per locale: catalogs["id"]["TASK_NOT_FOUND"] = "task tidak ditemukan"
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[string]map[string]string {
+	result := map[string]map[string]string{}
+
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: reading catalogs: %v", err))
+	}
+
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: reading catalog %s: %v", entry.Name(), err))
+		}
+
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+		result[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+
+	return result
+}
+
+// Supported mengembalikan daftar locale yang tersedia (terurut)
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported bernilai true jika locale memiliki katalog
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Translate mengembalikan pesan untuk kode pada locale tertentu
+// Fallback: locale yang diminta -> DefaultLocale -> fallback
+// Placeholder {nama} di pesan diganti dengan nilai dari params
+func Translate(locale, code, fallback string, params map[string]string) string {
+	message, ok := catalogs[locale][code]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][code]
+	}
+	if !ok {
+		message = fallback
+	}
+
+	for key, value := range params {
+		message = strings.ReplaceAll(message, "{"+key+"}", value)
+	}
+	return message
+}
+
+// Locale mengembalikan locale request yang disimpan oleh middleware locale
+func Locale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(LocalsKey).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// T adalah shortcut Translate dengan locale dari request
+func T(c *fiber.Ctx, code, fallback string, params map[string]string) string {
+	return Translate(Locale(c), code, fallback, params)
+}
+
+// Negotiate memilih locale terbaik dari header Accept-Language
+// Contoh header: "id-ID,id;q=0.9,en;q=0.8"
+// Returns: locale yang didukung dengan q-value tertinggi, atau DefaultLocale
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := DefaultLocale, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseLanguage(strings.TrimSpace(part))
+		if tag == "" || q <= bestQ {
+			continue
+		}
+
+		// Cocokkan tag lengkap dulu (contoh: "id"), lalu primary subtag (contoh: "id-ID" -> "id")
+		if IsSupported(tag) {
+			best, bestQ = tag, q
+		} else if primary := strings.SplitN(tag, "-", 2)[0]; IsSupported(primary) {
+			best, bestQ = primary, q
+		}
+	}
+
+	return best
+}
+
+// parseLanguage mem-parse satu item Accept-Language (contoh: "en-US;q=0.8")
+func parseLanguage(part string) (string, float64) {
+	tag, params, _ := strings.Cut(part, ";")
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || tag == "*" {
+		return "", 0
+	}
+
+	q := 1.0
+	if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+	return tag, q
+}
@@ -0,0 +1,138 @@
+// Package lifecycle mengelola siklus hidup aplikasi: readiness, background worker dan shutdown
+// Urutan shutdown:
+//  1. Readiness berubah menjadi tidak siap (load balancer berhenti mengirim request baru)
+//  2. HTTP server menyelesaikan request yang sedang berjalan (dilakukan di main.go)
+//  3. Context background worker dibatalkan dan ditunggu sampai selesai
+//  4. Shutdown hook dijalankan dengan urutan terbalik dari pendaftaran (contoh: tutup koneksi database)
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Manager menyimpan state lifecycle aplikasi
+// Aman dipakai dari banyak goroutine
+type Manager struct {
+	ready    atomic.Bool
+	stopping atomic.Bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// New membuat Manager baru, awalnya belum siap menerima traffic
+func New() *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{ctx: ctx, cancel: cancel}
+}
+
+// Ready bernilai true jika aplikasi siap menerima traffic
+func (m *Manager) Ready() bool {
+	return m.ready.Load() && !m.stopping.Load()
+}
+
+// SetReady menandai aplikasi siap (atau tidak siap) menerima traffic
+func (m *Manager) SetReady(ready bool) {
+	m.ready.Store(ready)
+}
+
+// Stopping bernilai true jika shutdown sudah dimulai
+func (m *Manager) Stopping() bool {
+	return m.stopping.Load()
+}
+
+// Go menjalankan background worker di goroutine terpisah
+// Context yang diberikan dibatalkan saat shutdown, worker wajib berhenti setelahnya
+func (m *Manager) Go(name string, fn func(ctx context.Context)) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("worker panic", slog.String("worker", name), slog.Any("panic", r))
+			}
+		}()
+		fn(m.ctx)
+	}()
+}
+
+// Every menjalankan fn secara berkala sampai shutdown
+// Error dari fn hanya di-log, worker tetap berjalan pada interval berikutnya
+func (m *Manager) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	m.Go(name, func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					slog.WarnContext(ctx, "worker run failed", slog.String("worker", name), slog.Any("error", err))
+				}
+			}
+		}
+	})
+}
+
+// OnShutdown mendaftarkan hook yang dijalankan saat shutdown
+// Hook dijalankan dengan urutan terbalik (hook yang didaftarkan terakhir dijalankan pertama)
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// BeginShutdown menandai shutdown dimulai, readiness langsung berubah menjadi tidak siap
+func (m *Manager) BeginShutdown() {
+	m.stopping.Store(true)
+}
+
+// Shutdown menghentikan background worker lalu menjalankan shutdown hook
+// ctx membatasi total waktu shutdown, error dari setiap hook dikumpulkan
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.BeginShutdown()
+	m.cancel()
+
+	// Tunggu worker selesai, tetapi jangan melebihi batas waktu shutdown
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	var errs []error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("background workers: %w", ctx.Err()))
+	}
+
+	m.mu.Lock()
+	hooks := append([]hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+// Package logger menyiapkan structured logging berbasis log/slog
+// Setiap log yang ditulis dengan slog.*Context(ctx, ...) otomatis berisi request_id, user_id
+// dan trace_id dari context request, sehingga log bisa dikorelasikan dengan trace dan error response
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"rest-api/pkg/config"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Format log yang didukung
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Redacted adalah nilai pengganti untuk atribut yang berisi secret
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys adalah potongan nama atribut yang nilainya tidak boleh ditulis ke log
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	userIDKey
+)
+
+// Setup membuat logger sesuai LOG_LEVEL dan LOG_FORMAT lalu memasangnya sebagai default slog
+// Output package log standar juga diarahkan ke logger ini
+func Setup(cfg *config.Config) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL %q (debug/info/warn/error)", cfg.LogLevel)
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.LogFormat) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return nil, fmt.Errorf("invalid LOG_FORMAT %q (json/text)", cfg.LogFormat)
+	}
+
+	logger := slog.New(contextHandler{handler})
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// redact mengganti nilai atribut sensitif (contoh: password, Authorization) dengan [REDACTED]
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(attr.Key, Redacted)
+		}
+	}
+	return attr
+}
+
+// WithRequestID menyimpan request ID di context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID mengambil request ID dari context, string kosong jika tidak ada
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithUserID menyimpan ID user yang sedang login di context
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID mengambil ID user dari context, 0 jika request tidak terautentikasi
+func UserID(ctx context.Context) uint {
+	userID, _ := ctx.Value(userIDKey).(uint)
+	return userID
+}
+
+// contextHandler menambahkan atribut korelasi dari context ke setiap log record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle implements slog.Handler.
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := RequestID(ctx); requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID := UserID(ctx); userID != 0 {
+			record.AddAttrs(slog.Any("user_id", userID))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
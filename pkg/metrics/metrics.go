@@ -0,0 +1,88 @@
+// Package metrics menyediakan metric Prometheus untuk HTTP, database dan business event
+// Semua metric didaftarkan ke Registry milik aplikasi (bukan default registry global)
+// sehingga isi /metrics hanya berisi metric yang memang kita definisikan
+package metrics
+
+import (
+	"database/sql"
+
+	"rest-api/pkg/apperror"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Namespace adalah prefix semua metric aplikasi
+const Namespace = "taskapi"
+
+// Registry menyimpan semua metric yang diekspos di /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests menghitung request per method, route template dan status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Total HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration mencatat latency request per method, route template dan status
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// Logins menghitung percobaan login, reason berisi kode error jika gagal
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "auth_logins_total",
+		Help:      "Login attempts by result (success/failure) and failure reason.",
+	}, []string{"result", "reason"})
+
+	// TasksCreated menghitung task yang dibuat
+	TasksCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "tasks_created_total",
+		Help:      "Total tasks created.",
+	})
+
+	// TasksCompleted menghitung task yang ditandai selesai
+	TasksCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "tasks_completed_total",
+		Help:      "Total tasks marked as completed.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		Logins,
+		TasksCreated,
+		TasksCompleted,
+	)
+}
+
+// RegisterDB mendaftarkan statistik connection pool database (open, in use, idle, wait, dst.)
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RecordLogin mencatat hasil login dari error yang dikembalikan auth.Service
+func RecordLogin(err error) {
+	if err == nil {
+		Logins.WithLabelValues("success", "").Inc()
+		return
+	}
+
+	reason := apperror.Code(err)
+	if reason == "" {
+		reason = "UNKNOWN"
+	}
+	Logins.WithLabelValues("failure", reason).Inc()
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute adalah label route untuk request yang tidak cocok dengan route manapun
+// Path asli tidak dipakai sebagai label agar cardinality tetap terbatas
+const unmatchedRoute = "unmatched"
+
+// Middleware mencatat jumlah dan latency request
+// Label route memakai template (contoh: /api/tasks/:id), bukan path asli
+// Method di-clone karena string dari fiber hanya valid selama request berlangsung
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// Jalankan ErrorHandler di sini agar status code error response ikut tercatat
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		labels := []string{strings.Clone(c.Method()), routeTemplate(c), strconv.Itoa(status)}
+		HTTPRequests.WithLabelValues(labels...).Inc()
+		HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// routeTemplate mengembalikan template route yang menangani request
+// Request yang hanya melewati middleware (app.Use) dianggap tidak cocok dengan route manapun
+func routeTemplate(c *fiber.Ctx) string {
+	route := c.Route()
+	if route == nil || route.Method == "USE" || route.Path == "" || route.Path == "/" && c.Path() != "/" {
+		return unmatchedRoute
+	}
+	return route.Path
+}
+
+// Handler mengekspos Registry dalam format Prometheus text
+// Jika token tidak kosong, request wajib mengirim header Authorization: Bearer <token>
+func Handler(token string) fiber.Handler {
+	handler := adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+
+	return func(c *fiber.Ctx) error {
+		if token != "" {
+			provided := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				return c.SendStatus(fiber.StatusUnauthorized)
+			}
+		}
+		return handler(c)
+	}
+}
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"strings"
+
+	"rest-api/pkg/auditlog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditContext menyimpan IP dan user agent client di c.UserContext()
+// sehingga setiap event audit yang dicatat service berisi informasi client
+func AuditContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.SetUserContext(auditlog.WithClient(c.UserContext(), auditlog.Client{
+			IP:        strings.Clone(c.IP()),
+			UserAgent: strings.Clone(c.Get(fiber.HeaderUserAgent)),
+		}))
+		return c.Next()
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"rest-api/internal/auth"
 	"rest-api/internal/database"
 	"rest-api/pkg/config"
+	"rest-api/pkg/i18n"
+	"rest-api/pkg/logger"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -43,9 +45,7 @@ func Auth(cfg *config.Config) fiber.Handler {
 
 		// Jika token tidak ditemukan di header maupun cookie
 		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "Akses ditolak. Token tidak ditemukan.",
-			})
+			return auth.ErrTokenMissing
 		}
 
 		// Parse dan verify JWT token
@@ -56,20 +56,37 @@ func Auth(cfg *config.Config) fiber.Handler {
 
 		// Jika token invalid atau expired
 		if err != nil || !tkn.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "Token tidak valid atau kadaluarsa.",
-			})
+			return auth.ErrTokenInvalid
 		}
 		// Ambil user dari database berdasarkan ID di claims
 		var user auth.User
-		if err := database.DB.First(&user, claims.ID).Error; err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "User tidak ditemukan.",
-			})
+		if err := database.DB.WithContext(c.UserContext()).First(&user, claims.ID).Error; err != nil {
+			return auth.ErrUserNotFound
+		}
+		// Akun yang dinonaktifkan admin tidak bisa mengakses API walaupun token masih berlaku
+		if user.IsDisabled() {
+			return auth.ErrAccountDisabled
+		}
+		// Preferensi bahasa user menimpa hasil negosiasi Accept-Language
+		if user.Locale != "" && i18n.IsSupported(user.Locale) {
+			setLocale(c, user.Locale)
 		}
 		// Simpan user object di context untuk digunakan di handler
 		// Cara akses di handler: user := c.Locals("user").(*auth.User)
 		c.Locals("user", &user)
+		c.SetUserContext(logger.WithUserID(c.UserContext(), user.ID))
 		return c.Next() // Lanjut ke handler berikutnya
 	}
 }
+
+// Admin membatasi route hanya untuk user dengan role admin
+// Harus dipasang setelah Auth: app.Get("/admin", middleware.Auth(cfg), middleware.Admin(), handler)
+func Admin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*auth.User)
+		if !ok || !user.IsAdmin() {
+			return auth.ErrAdminRequired
+		}
+		return c.Next()
+	}
+}
@@ -2,31 +2,62 @@
 package middlewares
 
 import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/i18n"
+	"rest-api/pkg/response"
+	"rest-api/pkg/validation"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 // ErrorHandler adalah custom error handler untuk Fiber
-// Function ini akan dipanggil saat terjadi error di aplikasi
+// Function ini akan dipanggil saat handler mengembalikan error
 // Function ini di-set di Fiber config saat inisialisasi app
+// Mapping error:
+//   - *apperror.Error: status dari kategori error, code dan message dari error
+//   - *fiber.Error: status dari error, code dari HTTP status (contoh: METHOD_NOT_ALLOWED)
+//   - error lain: 500 Internal Server Error, message asli tidak dikirim ke client
 // Parameters:
 //   - c: Fiber context
 //   - err: Error yang terjadi
 // Returns: error (selalu nil karena sudah di-handle)
 func ErrorHandler(c *fiber.Ctx, err error) error {
-	// Default status code 500 Internal Server Error
-	code := fiber.StatusInternalServerError
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		status := apperror.Status(appErr)
+		if status >= fiber.StatusInternalServerError {
+			logError(c, err)
+		}
+		details := appErr.Details
+		if fieldErrors, ok := details.(validation.Errors); ok {
+			details = fieldErrors.Localize(i18n.Locale(c))
+		}
+		return response.Problem(c, status, appErr.Code, appErr.Message, appErr.Params, details)
+	}
 
-	// Jika error adalah Fiber error, gunakan status code dari error tersebut
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return response.Problem(c, fiberErr.Code, statusCode(fiberErr.Code), fiberErr.Message, nil, nil)
 	}
 
-	// Return JSON response dengan error message
-	// Stack trace tidak ditampilkan untuk security (production)
-	return c.Status(code).JSON(fiber.Map{
-		"message": err.Error(),
-		"stack":   nil, // Don't expose stack trace in production
-	})
+	// Error yang tidak dikenal tidak di-expose ke client untuk security
+	logError(c, err)
+	status := fiber.StatusInternalServerError
+	return response.Problem(c, status, statusCode(status), http.StatusText(status), nil, nil)
+}
+
+// logError menulis error internal ke log beserta request ID untuk korelasi dengan response
+func logError(c *fiber.Ctx, err error) {
+	slog.ErrorContext(c.UserContext(), "request failed",
+		slog.String("method", c.Method()),
+		slog.String("path", c.Path()),
+		slog.Any("error", err),
+	)
 }
 
 // NotFound adalah handler untuk 404 Not Found
@@ -36,7 +67,15 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 //   - c: Fiber context
 // Returns: error
 func NotFound(c *fiber.Ctx) error {
-	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-		"message": "NOT FOUND - " + c.OriginalURL(),
-	})
+	return apperror.NotFound("ROUTE_NOT_FOUND", "NOT FOUND - "+c.OriginalURL()).
+		WithParams(map[string]string{"path": c.OriginalURL()})
+}
+
+// statusCode membuat kode error dari HTTP status (contoh: 405 -> METHOD_NOT_ALLOWED)
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "UNKNOWN_ERROR"
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
 }
@@ -0,0 +1,26 @@
+// Package middleware contains custom middleware functions
+package middlewares
+
+import (
+	"rest-api/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale adalah middleware untuk menentukan bahasa response
+// Locale dipilih dari header Accept-Language (contoh: "id-ID,id;q=0.9,en;q=0.8")
+// Jika user sudah login dan memiliki preferensi bahasa, middleware Auth akan menimpanya
+// Usage: app.Use(middlewares.Locale())
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAcceptLanguage)
+		setLocale(c, i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage)))
+		return c.Next()
+	}
+}
+
+// setLocale menyimpan locale di context dan header Content-Language
+func setLocale(c *fiber.Ctx, locale string) {
+	c.Locals(i18n.LocalsKey, locale)
+	c.Set(fiber.HeaderContentLanguage, locale)
+}
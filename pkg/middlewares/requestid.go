@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"strings"
+
+	"rest-api/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// HeaderRequestID adalah header untuk menerima dan mengembalikan request ID
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength membatasi request ID dari client agar tidak dipakai untuk menyisipkan data besar ke log
+const maxRequestIDLength = 128
+
+// RequestID memberi setiap request sebuah ID untuk korelasi log, trace dan error response
+// ID dari header X-Request-ID (contoh: dari gateway) dipakai jika valid, selain itu dibuat UUID baru
+// ID dikembalikan di response header X-Request-ID dan disimpan di c.UserContext()
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := strings.Clone(c.Get(HeaderRequestID))
+		if !validRequestID(requestID) {
+			requestID = utils.UUIDv4()
+		}
+
+		c.Set(HeaderRequestID, requestID)
+		c.SetUserContext(logger.WithRequestID(c.UserContext(), requestID))
+		return c.Next()
+	}
+}
+
+// validRequestID hanya menerima karakter yang aman ditulis ke log dan header
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:", r)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+// Package rank membuat key urutan (fractional index) berbentuk string untuk manual ordering
+// Key diurutkan secara leksikografis, sehingga memindahkan item cukup mengubah key item itu saja:
+// key baru dibuat di antara key tetangganya dengan Between
+//
+// Key memakai digit base-36 (0-9a-z) dan tidak pernah diakhiri '0', agar selalu ada ruang di bawahnya
+// Key bertambah panjang jika item sering disisipkan di posisi yang sama, gunakan Spread untuk
+// membagi ulang key (rebalance) jika panjangnya melebihi MaxLength
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength adalah panjang key yang dianggap terlalu panjang dan perlu di-rebalance
+// Tercapai setelah ±570 sisipan berturut-turut di awal/akhir list, atau ±150 sisipan di celah yang sama
+const MaxLength = 32
+
+var (
+	ErrInvalidKey   = errors.New("rank: invalid key")
+	ErrInvalidRange = errors.New("rank: lower bound must be less than upper bound")
+)
+
+// Between mengembalikan key di antara lower dan upper (lower < key < upper)
+// lower kosong berarti awal list, upper kosong berarti akhir list
+// Key di awal atau akhir list dibuat dengan menaikkan/menurunkan satu digit agar tetap pendek
+func Between(lower, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) {
+		return "", ErrInvalidKey
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return "", ErrInvalidRange
+	}
+
+	switch {
+	case lower == "" && upper == "":
+		return string(digits[base/2]), nil
+	case upper == "":
+		return after(lower), nil
+	case lower == "":
+		return before(upper), nil
+	default:
+		return midpoint(lower, upper), nil
+	}
+}
+
+// Spread membuat n key berurutan dengan jarak yang sama, dipakai untuk rebalance
+func Spread(n int) []string {
+	width, space := 1, base
+	for space <= n {
+		width++
+		space *= base
+	}
+
+	step := space / (n + 1)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strings.TrimRight(encode((i+1)*step, width), "0")
+	}
+	return keys
+}
+
+// after mengembalikan key terpendek yang lebih besar dari a
+func after(a string) string {
+	for i := 0; i < len(a); i++ {
+		if d := strings.IndexByte(digits, a[i]); d < base-1 {
+			return a[:i] + string(digits[d+1])
+		}
+	}
+	return a + string(digits[base/2])
+}
+
+// before mengembalikan key pendek yang lebih kecil dari b
+func before(b string) string {
+	for i := 0; i < len(b); i++ {
+		switch d := strings.IndexByte(digits, b[i]); {
+		case d > 1:
+			return b[:i] + string(digits[d-1])
+		case d == 1:
+			return b[:i] + "0" + string(digits[base/2])
+		}
+	}
+	return midpoint("", b)
+}
+
+// midpoint mengembalikan key di antara a dan b, b kosong berarti tak hingga
+func midpoint(a, b string) string {
+	// Lewati prefix yang sama, a dianggap diisi '0' setelah karakter terakhirnya
+	n := 0
+	for n < len(b) && digitAt(a, n) == strings.IndexByte(digits, b[n]) {
+		n++
+	}
+	if n > 0 {
+		return b[:n] + midpoint(suffix(a, n), b[n:])
+	}
+
+	da := digitAt(a, 0)
+	db := base
+	if b != "" {
+		db = strings.IndexByte(digits, b[0])
+	}
+	if db-da > 1 {
+		return string(digits[(da+db)/2])
+	}
+
+	// Digit pertama berurutan: b[0] saja sudah di antara a dan b jika b lebih panjang
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[da]) + midpoint(suffix(a, 1), "")
+}
+
+// digitAt mengembalikan nilai digit ke-i dari key, 0 jika melewati panjang key
+func digitAt(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+	return strings.IndexByte(digits, key[i])
+}
+
+func suffix(key string, i int) string {
+	if i >= len(key) {
+		return ""
+	}
+	return key[i:]
+}
+
+// encode menulis value sebagai digit base-36 dengan panjang width
+func encode(value, width int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = digits[value%base]
+		value /= base
+	}
+	return string(buf)
+}
+
+// valid bernilai true jika key kosong atau hanya berisi digit dan tidak diakhiri '0'
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return key == "" || key[len(key)-1] != '0'
+}
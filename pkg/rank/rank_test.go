@@ -0,0 +1,166 @@
+package rank
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// assertBetween memastikan key valid dan lower < key < upper (bound kosong berarti tak terbatas)
+func assertBetween(t *testing.T, lower, upper, key string) {
+	t.Helper()
+	if !valid(key) || key == "" {
+		t.Fatalf("Between(%q, %q) = %q, not a valid key", lower, upper, key)
+	}
+	if (lower != "" && key <= lower) || (upper != "" && key >= upper) {
+		t.Fatalf("Between(%q, %q) = %q, not strictly between", lower, upper, key)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		lower, upper string
+		want         string
+	}{
+		{"", "", "i"},
+		{"", "i", "h"},
+		{"i", "", "j"},
+		{"", "1", "0i"},
+		{"", "01", "00i"},
+		{"z", "", "zi"},
+		{"zz", "", "zzi"},
+		{"a", "c", "b"},
+		{"a", "b", "ai"},
+		{"a", "a1", "a0i"},
+		{"ay", "az", "ayi"},
+		{"az", "b", "azi"},
+		{"a", "b1", "b"},
+		{"0i", "1", "0r"},
+		{"9", "a", "9i"},
+		{"abc", "abd", "abci"},
+	}
+
+	for _, tt := range tests {
+		got, err := Between(tt.lower, tt.upper)
+		if err != nil {
+			t.Fatalf("Between(%q, %q) error = %v", tt.lower, tt.upper, err)
+		}
+		assertBetween(t, tt.lower, tt.upper, got)
+		if got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, want %q", tt.lower, tt.upper, got, tt.want)
+		}
+	}
+}
+
+func TestBetweenErrors(t *testing.T) {
+	tests := []struct {
+		lower, upper string
+		want         error
+	}{
+		{"A", "", ErrInvalidKey},
+		{"", "a-", ErrInvalidKey},
+		{"a0", "", ErrInvalidKey},
+		{"", "0", ErrInvalidKey},
+		{"b", "a", ErrInvalidRange},
+		{"a", "a", ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		if _, err := Between(tt.lower, tt.upper); !errors.Is(err, tt.want) {
+			t.Errorf("Between(%q, %q) error = %v, want %v", tt.lower, tt.upper, err, tt.want)
+		}
+	}
+}
+
+// Sisipan acak di antara tetangga mana pun harus selalu menghasilkan key unik yang tetap terurut
+func TestBetweenRandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	keys := []string{}
+	for i := 0; i < 2000; i++ {
+		at := rng.Intn(len(keys) + 1)
+		lower, upper := "", ""
+		if at > 0 {
+			lower = keys[at-1]
+		}
+		if at < len(keys) {
+			upper = keys[at]
+		}
+		key, err := Between(lower, upper)
+		if err != nil {
+			t.Fatalf("Between(%q, %q) error = %v", lower, upper, err)
+		}
+		assertBetween(t, lower, upper, key)
+		keys = slices.Insert(keys, at, key)
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Fatalf("keys are not sorted after random inserts")
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 35, 36, 37, 1000, 1295, 1296, 50000} {
+		keys := Spread(n)
+		if len(keys) != n {
+			t.Fatalf("Spread(%d) returned %d keys", n, len(keys))
+		}
+		for i, key := range keys {
+			if !valid(key) || key == "" || len(key) > MaxLength {
+				t.Fatalf("Spread(%d)[%d] = %q, not a valid key", n, i, key)
+			}
+			if i > 0 && keys[i-1] >= key {
+				t.Fatalf("Spread(%d)[%d] = %q, not after %q", n, i, key, keys[i-1])
+			}
+		}
+		// Setelah rebalance masih ada ruang di awal dan akhir list
+		if n > 0 {
+			if _, err := Between("", keys[0]); err != nil {
+				t.Fatalf("Between before Spread(%d)[0] error = %v", n, err)
+			}
+			if _, err := Between(keys[n-1], ""); err != nil {
+				t.Fatalf("Between after Spread(%d)[last] error = %v", n, err)
+			}
+		}
+	}
+
+	if got := Spread(3); !slices.Equal(got, []string{"9", "i", "r"}) {
+		t.Errorf("Spread(3) = %v, want [9 i r]", got)
+	}
+}
+
+// Key bertambah satu karakter setiap ~17 sisipan di awal/akhir list dan setiap ~5 sisipan di celah yang sama
+// Test ini menjaga batas tersebut agar rebalance (Spread) tidak perlu sering dijalankan
+func TestRepeatedInsertsStayUnderMaxLength(t *testing.T) {
+	tests := []struct {
+		name         string
+		lower, upper string
+		inserts      int
+		next         func(lower, upper, key string) (string, string)
+	}{
+		// Task baru selalu ditaruh di paling atas
+		{"head", "", "", 500, func(lower, upper, key string) (string, string) { return "", key }},
+		// Task selalu dipindah ke paling bawah
+		{"tail", "", "", 500, func(lower, upper, key string) (string, string) { return key, "" }},
+		// Task selalu disisipkan di celah yang sama, tepat setelah/sebelum sisipan sebelumnya
+		{"same gap towards upper", "a", "b", 150, func(lower, upper, key string) (string, string) { return key, upper }},
+		{"same gap towards lower", "a", "b", 150, func(lower, upper, key string) (string, string) { return lower, key }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := tt.lower, tt.upper
+			for i := 0; i < tt.inserts; i++ {
+				key, err := Between(lower, upper)
+				if err != nil {
+					t.Fatalf("insert %d: Between(%q, %q) error = %v", i, lower, upper, err)
+				}
+				assertBetween(t, lower, upper, key)
+				if len(key) > MaxLength {
+					t.Fatalf("insert %d: key %q is longer than %d", i, key, MaxLength)
+				}
+				lower, upper = tt.next(lower, upper, key)
+			}
+		})
+	}
+}
@@ -1,35 +1,68 @@
 package response
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"net/http"
+
+	"rest-api/pkg/i18n"
+	"rest-api/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProblemContentType adalah media type untuk error response (RFC 7807)
+const ProblemContentType = "application/problem+json"
 
 // SuccessResponse is a standard success response for Swagger docs
 // @Description Success response
-// @example {"success":true,"message":"string","data":{}}
+// @example {"success":true,"code":"TASK_CREATED","message":"string","data":{}}
 type SuccessResponse struct {
 	Success bool        `json:"success" example:"true"`
+	Code    string      `json:"code" example:"TASK_CREATED"`
 	Message string      `json:"message" example:"Operation successful"`
 	Data    interface{} `json:"data"`
 }
 
-// ErrorResponse is a standard error response for Swagger docs
-// @Description Error response
-// @example {"success":false,"message":"error message"}
-type ErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Message string `json:"message" example:"error message"`
+// ProblemResponse is a standard RFC 7807 error response (application/problem+json)
+// @Description Problem details error response
+type ProblemResponse struct {
+	Type      string      `json:"type" example:"about:blank"`
+	Title     string      `json:"title" example:"Not Found"`
+	Status    int         `json:"status" example:"404"`
+	Detail    string      `json:"detail,omitempty" example:"task not found"`
+	Instance  string      `json:"instance,omitempty" example:"/api/tasks/1"`
+	Code      string      `json:"code" example:"TASK_NOT_FOUND"`
+	Errors    interface{} `json:"errors,omitempty"`
+	RequestID string      `json:"requestId,omitempty" example:"3f1c2a9e-8d4b-4c1e-9f6a-2b7d5e8c1a40"`
 }
 
-func Success(c *fiber.Ctx, status int, message string, data interface{}) error {
+// Success menulis success response dengan pesan sesuai locale request
+// Parameters:
+//   - code: kode pesan di katalog i18n (contoh: TASK_CREATED), selalu dikirim apa adanya di field code
+func Success(c *fiber.Ctx, status int, code string, data interface{}) error {
 	return c.Status(status).JSON(fiber.Map{
 		"success": true,
-		"message": message,
+		"code":    code,
+		"message": i18n.T(c, code, code, nil),
 		"data":    data,
 	})
 }
 
-func Error(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(fiber.Map{
-		"success": false,
-		"message": message,
-	})
+// Problem menulis error response berformat RFC 7807 (application/problem+json)
+// Parameters:
+//   - status: HTTP status code
+//   - code: kode error machine-readable (contoh: TASK_NOT_FOUND), juga key katalog i18n
+//   - detail: pesan default jika kode tidak ada di katalog
+//   - params: nilai placeholder untuk pesan terjemahan, boleh nil
+//   - errors: detail tambahan (contoh: error validasi per field), boleh nil
+func Problem(c *fiber.Ctx, status int, code, detail string, params map[string]string, errors interface{}) error {
+	return c.Status(status).JSON(ProblemResponse{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    i18n.T(c, code, detail, params),
+		Instance:  c.OriginalURL(),
+		Code:      code,
+		Errors:    errors,
+		RequestID: logger.RequestID(c.UserContext()),
+	}, ProblemContentType)
 }
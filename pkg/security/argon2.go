@@ -0,0 +1,148 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Default parameter Argon2id (mengikuti rekomendasi OWASP)
+const (
+	DefaultArgon2Memory      = 64 * 1024 // dalam KiB (64 MB)
+	DefaultArgon2Iterations  = 3
+	DefaultArgon2Parallelism = 2
+	DefaultArgon2SaltLength  = 16
+	DefaultArgon2KeyLength   = 32
+)
+
+// Rentang parameter Argon2id yang diterima dari konfigurasi
+// Iterations dan parallelism 0 membuat argon2.IDKey panic, parallelism dibatasi uint8
+const (
+	MinArgon2Memory      = 8
+	MaxArgon2Memory      = 4 * 1024 * 1024 // 4 GiB
+	MinArgon2Iterations  = 1
+	MaxArgon2Iterations  = 100
+	MinArgon2Parallelism = 1
+	MaxArgon2Parallelism = 255
+)
+
+// Panjang minimal salt dan key pada hash yang diverifikasi
+// Key kosong membuat password apa pun cocok, salt terlalu pendek memudahkan precomputation
+const (
+	MinArgon2SaltLength = 8
+	MinArgon2KeyLength  = 16
+)
+
+// ErrInvalidArgon2Hash dikembalikan jika encoded hash Argon2id tidak valid
+var ErrInvalidArgon2Hash = errors.New("invalid argon2id hash")
+
+// Argon2idHasher membuat hash password menggunakan Argon2id
+// Format hash: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+type Argon2idHasher struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// argon2Params adalah hasil decode dari encoded hash Argon2id
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+// Hash implements PasswordHasher.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements PasswordHasher.
+func (h *Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	p, err := decodeArgon2(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(password), p.salt, p.iterations, p.memory, p.parallelism, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+}
+
+// NeedsRehash implements PasswordHasher.
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	p, err := decodeArgon2(encoded)
+	if err != nil {
+		return true
+	}
+	return p.memory != h.Memory ||
+		p.iterations != h.Iterations ||
+		p.parallelism != h.Parallelism ||
+		uint32(len(p.salt)) != h.SaltLength ||
+		uint32(len(p.key)) != h.KeyLength
+}
+
+// Identify bernilai true untuk hash berprefix $argon2id$
+func (h *Argon2idHasher) Identify(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+// decodeArgon2 mem-parse encoded hash Argon2id berformat PHC
+func decodeArgon2(encoded string) (*argon2Params, error) {
+	// Format: ["", "argon2id", "v=19", "m=..,t=..,p=..", "<salt>", "<hash>"]
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, ErrInvalidArgon2Hash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, ErrInvalidArgon2Hash
+	}
+	if version != argon2.Version {
+		return nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	p := &argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return nil, ErrInvalidArgon2Hash
+	}
+	// Hash dari database tidak boleh membuat argon2.IDKey panic atau mengalokasikan memory/CPU di luar batas konfigurasi
+	if p.memory < MinArgon2Memory || p.memory > MaxArgon2Memory ||
+		p.iterations < MinArgon2Iterations || p.iterations > MaxArgon2Iterations ||
+		p.parallelism < MinArgon2Parallelism {
+		return nil, ErrInvalidArgon2Hash
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidArgon2Hash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, ErrInvalidArgon2Hash
+	}
+	if len(p.salt) < MinArgon2SaltLength || len(p.key) < MinArgon2KeyLength {
+		return nil, ErrInvalidArgon2Hash
+	}
+
+	return p, nil
+}
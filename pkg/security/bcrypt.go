@@ -0,0 +1,54 @@
+package security
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost adalah cost default bcrypt
+const DefaultBcryptCost = 10 // sama dengan bcrypt.DefaultCost
+
+// BcryptHasher membuat hash password menggunakan bcrypt
+// Format hash bcrypt ($2a$<cost>$<salt+hash>) tetap dipertahankan agar hash lama tetap valid
+type BcryptHasher struct {
+	Cost int
+}
+
+// Hash implements PasswordHasher.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Verify implements PasswordHasher.
+func (h *BcryptHasher) Verify(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NeedsRehash implements PasswordHasher.
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+	return cost != h.Cost
+}
+
+// Identify bernilai true untuk hash berprefix $2a$, $2b$ atau $2y$
+func (h *BcryptHasher) Identify(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
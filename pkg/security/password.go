@@ -0,0 +1,122 @@
+// Package security berisi utilitas keamanan yang dipakai bersama oleh modul-modul aplikasi
+// Saat ini berisi abstraksi hashing password (Argon2id dan bcrypt)
+package security
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"rest-api/pkg/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnknownHashFormat dikembalikan jika format hash tidak dikenali oleh hasher manapun
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// PasswordHasher adalah abstraksi untuk hashing dan verifikasi password
+// Hash yang dihasilkan berformat PHC string (contoh: $argon2id$v=19$m=65536,t=3,p=2$salt$hash)
+type PasswordHasher interface {
+	// Hash membuat encoded hash dari password plain text
+	Hash(password string) (string, error)
+	// Verify membandingkan password plain text dengan encoded hash
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash bernilai true jika hash dibuat dengan algoritma/parameter yang sudah usang
+	NeedsRehash(encoded string) bool
+}
+
+// algorithm adalah PasswordHasher untuk satu algoritma tertentu
+type algorithm interface {
+	PasswordHasher
+	// Identify bernilai true jika encoded hash dibuat oleh algoritma ini
+	Identify(encoded string) bool
+}
+
+// multiHasher selalu membuat hash dengan algoritma utama,
+// tetapi tetap bisa memverifikasi hash dari algoritma lain yang didukung
+type multiHasher struct {
+	primary    algorithm
+	algorithms []algorithm
+}
+
+// NewPasswordHasher membuat PasswordHasher berdasarkan konfigurasi aplikasi
+// Algoritma default adalah Argon2id, bcrypt tetap bisa diverifikasi
+// Parameter di luar rentang yang didukung dikembalikan sebagai error agar aplikasi gagal saat startup,
+// bukan saat login pertama
+func NewPasswordHasher(cfg *config.Config) (PasswordHasher, error) {
+	memory, err := parseParam("ARGON2_MEMORY", cfg.Argon2Memory, MinArgon2Memory, MaxArgon2Memory)
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := parseParam("ARGON2_ITERATIONS", cfg.Argon2Iterations, MinArgon2Iterations, MaxArgon2Iterations)
+	if err != nil {
+		return nil, err
+	}
+	parallelism, err := parseParam("ARGON2_PARALLELISM", cfg.Argon2Parallelism, MinArgon2Parallelism, MaxArgon2Parallelism)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := parseParam("BCRYPT_COST", cfg.BcryptCost, uint64(bcrypt.MinCost), uint64(bcrypt.MaxCost))
+	if err != nil {
+		return nil, err
+	}
+	// Spesifikasi Argon2 mensyaratkan memory minimal 8 KiB per thread
+	if memory < 8*parallelism {
+		return nil, fmt.Errorf("ARGON2_MEMORY must be at least 8 KiB per thread (%d for ARGON2_PARALLELISM=%d)", 8*parallelism, parallelism)
+	}
+
+	argon := &Argon2idHasher{
+		Memory:      uint32(memory),
+		Iterations:  uint32(iterations),
+		Parallelism: uint8(parallelism),
+		SaltLength:  DefaultArgon2SaltLength,
+		KeyLength:   DefaultArgon2KeyLength,
+	}
+	bcryptHasher := &BcryptHasher{Cost: int(cost)}
+
+	var primary algorithm = argon
+	if strings.EqualFold(cfg.PasswordHashAlgo, "bcrypt") {
+		primary = bcryptHasher
+	}
+
+	return &multiHasher{
+		primary:    primary,
+		algorithms: []algorithm{argon, bcryptHasher},
+	}, nil
+}
+
+// Hash implements PasswordHasher.
+func (h *multiHasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+// Verify implements PasswordHasher.
+func (h *multiHasher) Verify(password, encoded string) (bool, error) {
+	for _, alg := range h.algorithms {
+		if alg.Identify(encoded) {
+			return alg.Verify(password, encoded)
+		}
+	}
+	return false, ErrUnknownHashFormat
+}
+
+// NeedsRehash implements PasswordHasher.
+// Hash perlu dibuat ulang jika algoritmanya bukan algoritma utama
+// atau parameternya berbeda dengan konfigurasi saat ini
+func (h *multiHasher) NeedsRehash(encoded string) bool {
+	if !h.primary.Identify(encoded) {
+		return true
+	}
+	return h.primary.NeedsRehash(encoded)
+}
+
+// parseParam membaca parameter hashing dari string konfigurasi dan memastikan nilainya di antara low dan high
+func parseParam(name, value string, low, high uint64) (uint64, error) {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || n < low || n > high {
+		return 0, fmt.Errorf("%s must be a number between %d and %d, got %q", name, low, high, value)
+	}
+	return n, nil
+}
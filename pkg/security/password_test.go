@@ -0,0 +1,111 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"rest-api/pkg/config"
+)
+
+func validConfig() *config.Config {
+	return &config.Config{
+		PasswordHashAlgo:  "argon2id",
+		Argon2Memory:      "1024",
+		Argon2Iterations:  "1",
+		Argon2Parallelism: "1",
+		BcryptCost:        "4",
+	}
+}
+
+func TestNewPasswordHasherRejectsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+		want   string
+	}{
+		{"parallelism overflows uint8", func(cfg *config.Config) { cfg.Argon2Parallelism = "256" }, "ARGON2_PARALLELISM"},
+		{"parallelism zero", func(cfg *config.Config) { cfg.Argon2Parallelism = "0" }, "ARGON2_PARALLELISM"},
+		{"iterations zero", func(cfg *config.Config) { cfg.Argon2Iterations = "0" }, "ARGON2_ITERATIONS"},
+		{"memory not a number", func(cfg *config.Config) { cfg.Argon2Memory = "64MB" }, "ARGON2_MEMORY"},
+		{"memory below 8 KiB per thread", func(cfg *config.Config) { cfg.Argon2Memory = "8"; cfg.Argon2Parallelism = "4" }, "ARGON2_MEMORY"},
+		{"bcrypt cost too low", func(cfg *config.Config) { cfg.BcryptCost = "3" }, "BCRYPT_COST"},
+		{"bcrypt cost too high", func(cfg *config.Config) { cfg.BcryptCost = "32" }, "BCRYPT_COST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			_, err := NewPasswordHasher(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("NewPasswordHasher() error = %v, want error mentioning %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordHasherRoundTrip(t *testing.T) {
+	for _, algo := range []string{"argon2id", "bcrypt"} {
+		t.Run(algo, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.PasswordHashAlgo = algo
+			hasher, err := NewPasswordHasher(cfg)
+			if err != nil {
+				t.Fatalf("NewPasswordHasher() error = %v", err)
+			}
+
+			encoded, err := hasher.Hash("secret12")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if ok, err := hasher.Verify("secret12", encoded); !ok || err != nil {
+				t.Fatalf("Verify(correct) = %v, %v", ok, err)
+			}
+			if ok, _ := hasher.Verify("wrong", encoded); ok {
+				t.Fatal("Verify(wrong) = true")
+			}
+			if hasher.NeedsRehash(encoded) {
+				t.Fatal("NeedsRehash() = true for hash created with current params")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsUnsafeArgon2Hash(t *testing.T) {
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s"
+	hasher := &Argon2idHasher{}
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"parallelism 0", "$argon2id$v=19$m=1024,t=1,p=0$" + salt + "$" + key},
+		{"iterations 0", "$argon2id$v=19$m=1024,t=0,p=1$" + salt + "$" + key},
+		{"parallelism overflow", "$argon2id$v=19$m=1024,t=1,p=256$" + salt + "$" + key},
+		{"memory too large", "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key},
+		{"memory too small", "$argon2id$v=19$m=1,t=1,p=1$" + salt + "$" + key},
+		{"iterations too large", "$argon2id$v=19$m=1024,t=1000000,p=1$" + salt + "$" + key},
+		{"empty key", "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$"},
+		{"short key", "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$a2tra2tra2tra2tra2tr"},
+		{"empty salt", "$argon2id$v=19$m=1024,t=1,p=1$$" + key},
+		{"short salt", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$" + key},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ok, err := hasher.Verify("secret12", tt.encoded); ok || !errors.Is(err, ErrInvalidArgon2Hash) {
+				t.Fatalf("Verify(%q) = %v, %v, want %v", tt.encoded, ok, err, ErrInvalidArgon2Hash)
+			}
+		})
+	}
+
+	// Hash dengan panjang minimal tetap bisa diverifikasi
+	valid := &Argon2idHasher{Memory: MinArgon2Memory, Iterations: 1, Parallelism: 1, SaltLength: MinArgon2SaltLength, KeyLength: MinArgon2KeyLength}
+	encoded, err := valid.Hash("secret12")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if ok, err := hasher.Verify("secret12", encoded); !ok || err != nil {
+		t.Fatalf("Verify(%q) = %v, %v, want true", encoded, ok, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package tracing menyediakan OpenTelemetry tracing untuk HTTP, service dan database
+// Span dibuat di tiga lapisan:
+//   - HTTP: satu span per request Fiber (Middleware), trace context dari header traceparent (W3C)
+//   - Service: satu child span per method service (lihat tracing.go di setiap modul)
+//   - Database: satu child span per query GORM (GormPlugin)
+//
+// Exporter dipilih lewat TRACING_EXPORTER (none/stdout/otlp)
+// Untuk test, gunakan NewProvider dengan exporter in-memory:
+//
+//	exporter := tracetest.NewInMemoryExporter()
+//	tp := tracing.NewProvider(exporter, "test", 1)
+//	// ... jalankan request / service ...
+//	tp.ForceFlush(ctx)
+//	spans := exporter.GetSpans()
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName adalah nama instrumentation scope untuk semua span aplikasi
+const TracerName = "rest-api"
+
+// Exporter yang didukung
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Tracer mengembalikan tracer dari provider global
+// Selama provider belum dipasang (contoh: di cmd/taskctl), span yang dibuat adalah no-op
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Setup memasang TracerProvider global sesuai konfigurasi
+// Returns: function untuk flush dan menutup exporter saat shutdown
+func Setup(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch strings.ToLower(cfg.TracingExporter) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		// Endpoint dan header dibaca dari OTEL_EXPORTER_OTLP_ENDPOINT / OTEL_EXPORTER_OTLP_HEADERS
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q (supported: none, stdout, otlp)", cfg.TracingExporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ratio, err := strconv.ParseFloat(cfg.TracingSampleRatio, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO %q (must be between 0 and 1)", cfg.TracingSampleRatio)
+	}
+
+	tp := NewProvider(exporter, cfg.TracingServiceName, ratio)
+	return tp.Shutdown, nil
+}
+
+// NewProvider membuat TracerProvider dengan exporter yang diberikan lalu memasangnya sebagai provider global
+// ratio adalah proporsi trace baru yang disampling (0-1), trace dari upstream mengikuti keputusan parent
+func NewProvider(exporter sdktrace.SpanExporter, serviceName string, ratio float64) *sdktrace.TracerProvider {
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp
+}
+
+// Start membuat child span dari span yang ada di ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End menutup span dan mencatat error jika ada
+// Error domain (4xx, contoh: TASK_NOT_FOUND) hanya dicatat sebagai atribut error.code,
+// status span Error hanya untuk error 5xx agar span error benar-benar menandakan kegagalan sistem
+func End(span trace.Span, err error) {
+	defer span.End()
+	if err == nil {
+		return
+	}
+
+	if code := apperror.Code(err); code != "" {
+		span.SetAttributes(attribute.String("error.code", code))
+	}
+	if apperror.Status(err) >= http.StatusInternalServerError {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
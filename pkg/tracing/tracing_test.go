@@ -0,0 +1,177 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"rest-api/pkg/tracing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	upstreamTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	upstreamSpanID  = "00f067aa0ba902b7"
+)
+
+// setup memasang provider dengan exporter in-memory dan app Fiber dengan handler -> service -> query GORM
+func setup(t *testing.T) (*fiber.App, *sdktrace.TracerProvider, *tracetest.InMemoryExporter, *propagation.MapCarrier) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracing.NewProvider(exporter, "test", 1)
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		t.Fatalf("use gorm plugin: %v", err)
+	}
+
+	// outgoing menyimpan header trace context yang akan dikirim service ke sistem lain
+	outgoing := &propagation.MapCarrier{}
+	service := func(ctx context.Context, id string) (err error) {
+		ctx, span := tracing.Start(ctx, "item.GetItem", attribute.String("item.id", id))
+		defer func() { tracing.End(span, err) }()
+
+		otel.GetTextMapPropagator().Inject(ctx, outgoing)
+		var count int64
+		return db.WithContext(ctx).Table("sqlite_master").Count(&count).Error
+	}
+
+	app := fiber.New()
+	app.Use(tracing.Middleware())
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		if err := service(c.UserContext(), c.Params("id")); err != nil {
+			return err
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.ErrServiceUnavailable
+	})
+	return app, tp, exporter, outgoing
+}
+
+// spansByName mengembalikan span yang sudah diekspor berdasarkan nama
+func spansByName(t *testing.T, tp *sdktrace.TracerProvider, exporter *tracetest.InMemoryExporter) map[string]tracetest.SpanStub {
+	t.Helper()
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+func TestSpanTreeFollowsUpstreamTraceparent(t *testing.T) {
+	app, tp, exporter, outgoing := setup(t)
+
+	req := httptest.NewRequest("GET", "/items/7", nil)
+	req.Header.Set("traceparent", "00-"+upstreamTraceID+"-"+upstreamSpanID+"-01")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if res.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("status = %d, want %d", res.StatusCode, fiber.StatusNoContent)
+	}
+
+	spans := spansByName(t, tp, exporter)
+	server, ok := spans["GET /items/:id"]
+	if !ok {
+		t.Fatalf("missing HTTP span named after route template, got %v", names(spans))
+	}
+	service, ok := spans["item.GetItem"]
+	if !ok {
+		t.Fatalf("missing service span, got %v", names(spans))
+	}
+	query, ok := spans["gorm.query"]
+	if !ok {
+		t.Fatalf("missing gorm span, got %v", names(spans))
+	}
+
+	if got := server.SpanContext.TraceID().String(); got != upstreamTraceID {
+		t.Errorf("HTTP span trace ID = %s, want upstream %s", got, upstreamTraceID)
+	}
+	if got := server.Parent.SpanID().String(); got != upstreamSpanID || !server.Parent.IsRemote() {
+		t.Errorf("HTTP span parent = %s (remote %v), want remote %s", got, server.Parent.IsRemote(), upstreamSpanID)
+	}
+	if server.SpanKind != trace.SpanKindServer {
+		t.Errorf("HTTP span kind = %v, want server", server.SpanKind)
+	}
+	assertChild(t, "service", service, server)
+	assertChild(t, "gorm", query, service)
+	if query.SpanKind != trace.SpanKindClient {
+		t.Errorf("gorm span kind = %v, want client", query.SpanKind)
+	}
+
+	// Context yang diteruskan ke sistem lain membawa trace yang sama dengan span service sebagai parent
+	want := "00-" + upstreamTraceID + "-" + service.SpanContext.SpanID().String() + "-01"
+	if got := outgoing.Get("traceparent"); got != want {
+		t.Errorf("outgoing traceparent = %q, want %q", got, want)
+	}
+}
+
+func TestSpanWithoutTraceparentStartsNewTrace(t *testing.T) {
+	app, tp, exporter, _ := setup(t)
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/items/7", nil)); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	server := spansByName(t, tp, exporter)["GET /items/:id"]
+	if server.Parent.IsValid() {
+		t.Errorf("HTTP span parent = %v, want root span", server.Parent.SpanID())
+	}
+	if !server.SpanContext.TraceID().IsValid() || server.SpanContext.TraceID().String() == upstreamTraceID {
+		t.Errorf("HTTP span trace ID = %s, want new trace", server.SpanContext.TraceID())
+	}
+}
+
+func TestServerErrorMarksSpan(t *testing.T) {
+	app, tp, exporter, _ := setup(t)
+
+	res, err := app.Test(httptest.NewRequest("GET", "/fail", nil))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	server := spansByName(t, tp, exporter)["GET /fail"]
+	if server.Status.Code != codes.Error {
+		t.Errorf("span status = %v for HTTP %d, want error", server.Status.Code, res.StatusCode)
+	}
+}
+
+func assertChild(t *testing.T, name string, child, parent tracetest.SpanStub) {
+	t.Helper()
+	if child.SpanContext.TraceID() != parent.SpanContext.TraceID() {
+		t.Errorf("%s span trace ID = %s, want %s", name, child.SpanContext.TraceID(), parent.SpanContext.TraceID())
+	}
+	if child.Parent.SpanID() != parent.SpanContext.SpanID() {
+		t.Errorf("%s span parent = %s, want %s (%s)", name, child.Parent.SpanID(), parent.SpanContext.SpanID(), parent.Name)
+	}
+}
+
+func names(spans map[string]tracetest.SpanStub) []string {
+	result := make([]string, 0, len(spans))
+	for name := range spans {
+		result = append(result, name)
+	}
+	return result
+}
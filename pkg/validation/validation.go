@@ -0,0 +1,148 @@
+// Package validation menjalankan validasi request DTO berdasarkan struct tag `validate`
+// Menggunakan go-playground/validator dan mengembalikan detail error per field
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"rest-api/pkg/apperror"
+	"rest-api/pkg/i18n"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrInvalidBody dikembalikan jika body request tidak bisa di-parse
+var ErrInvalidBody = apperror.BadRequest("INVALID_BODY", "Invalid request body")
+
+// ErrInvalidQuery dikembalikan jika query string tidak bisa di-parse (contoh: angka tidak valid)
+var ErrInvalidQuery = apperror.BadRequest("INVALID_QUERY", "Invalid query parameters")
+
+// ErrValidationFailed dikembalikan jika ada field yang tidak valid, detail per field ada di Details
+var ErrValidationFailed = apperror.Validation("VALIDATION_FAILED", "Validation failed", nil)
+
+// FieldError adalah detail error validasi untuk satu field
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Rule    string `json:"rule" example:"email"`
+	Param   string `json:"param,omitempty" example:""`
+	Message string `json:"message" example:"email must be a valid email address"`
+
+	kind string // reflect.Kind dari field, menentukan variasi pesan min/max
+}
+
+// Errors adalah kumpulan error validasi, mengimplementasikan interface error
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validate adalah instance validator yang dipakai bersama (thread-safe dan meng-cache struct info)
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Gunakan nama field dari tag json agar sama dengan yang dikirim client
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	return v
+}
+
+// Struct memvalidasi struct berdasarkan tag `validate`
+// Returns: ErrValidationFailed dengan Details berisi Errors jika ada field yang tidak valid, nil jika valid
+func Struct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperror.Internal("VALIDATION_ERROR", "failed to validate request", err)
+	}
+
+	result := make(Errors, len(validationErrors))
+	for i, fe := range validationErrors {
+		result[i] = FieldError{
+			Field: fieldPath(fe),
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+			kind:  fe.Kind().String(),
+		}
+	}
+	return ErrValidationFailed.WithDetails(result.Localize(i18n.DefaultLocale))
+}
+
+// ParseBody mem-parse body request ke out lalu memvalidasinya
+// Returns: ErrInvalidBody jika body tidak valid, ErrValidationFailed jika validasi gagal
+func ParseBody(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return ErrInvalidBody
+	}
+	return Struct(out)
+}
+
+// ParseQuery mem-parse query string ke out (tag `query`) lalu memvalidasinya
+// Returns: ErrInvalidQuery jika query tidak valid, ErrValidationFailed jika validasi gagal
+func ParseQuery(c *fiber.Ctx, out interface{}) error {
+	if err := c.QueryParser(out); err != nil {
+		return ErrInvalidQuery
+	}
+	return Struct(out)
+}
+
+// fieldPath mengembalikan path field tanpa nama struct root (contoh: "email", "items[0].title")
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+// messageKey mengembalikan key katalog i18n untuk rule validasi
+// Rule min/max punya pesan khusus untuk string (jumlah karakter)
+func messageKey(rule, kind string) string {
+	switch rule {
+	case "required", "email", "url", "oneof":
+		return "validation." + rule
+	case "min", "max":
+		if kind == reflect.String.String() {
+			return "validation." + rule + ".string"
+		}
+		return "validation." + rule
+	default:
+		return "validation.default"
+	}
+}
+
+// Localize mengembalikan salinan Errors dengan pesan dalam locale tertentu
+func (e Errors) Localize(locale string) Errors {
+	localized := make(Errors, len(e))
+	for i, fe := range e {
+		fe.Message = i18n.Translate(locale, messageKey(fe.Rule, fe.kind), fe.Message, map[string]string{
+			"field": fe.Field,
+			"param": fe.Param,
+			"rule":  fe.Rule,
+		})
+		localized[i] = fe
+	}
+	return localized
+}